@@ -0,0 +1,153 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/kataras/golog"
+	"github.com/sirupsen/logrus"
+)
+
+// encodingJSONFormatter is an `encoding/json`-based reference implementation
+// of the JSON formatter: it writes the logs through a cached `encoding/json` encoder
+// and the fields through a reflection-based `json.Marshal` of their values.
+type encodingJSONFormatter struct {
+	mu       sync.Mutex
+	encoders map[io.Writer]*json.Encoder
+}
+
+func (f *encodingJSONFormatter) String() string                      { return "encoding/json" }
+func (f *encodingJSONFormatter) Options(opts ...any) golog.Formatter { return f }
+func (f *encodingJSONFormatter) Format(dest io.Writer, log *golog.Log) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	enc, ok := f.encoders[dest]
+	if !ok {
+		if f.encoders == nil {
+			f.encoders = make(map[io.Writer]*json.Encoder)
+		}
+		enc = json.NewEncoder(dest)
+		enc.SetIndent("", "")
+		f.encoders[dest] = enc
+	}
+
+	return enc.Encode(encodingJSONLog{
+		Timestamp:  log.Timestamp,
+		Level:      log.Level,
+		Message:    log.Message,
+		Fields:     encodingJSONFields(log.Fields),
+		Caller:     log.Caller,
+		Stacktrace: log.Stacktrace,
+	}) == nil
+}
+
+// encodingJSONLog is the JSON shape of the `golog.Log` of the reference implementation.
+type encodingJSONLog struct {
+	Timestamp  int64              `json:"timestamp,omitempty"`
+	Level      golog.Level        `json:"level"`
+	Message    string             `json:"message"`
+	Fields     encodingJSONFields `json:"fields,omitempty"`
+	Caller     golog.Frame        `json:"caller,omitzero"`
+	Stacktrace []golog.Frame      `json:"stacktrace,omitempty"`
+}
+
+// encodingJSONFields is the `golog.FieldList` with a reflection-based `MarshalJSON`.
+type encodingJSONFields golog.FieldList
+
+// MarshalJSON encodes the fields as a JSON object, in order.
+// Nested groups are encoded as nested objects.
+func (l encodingJSONFields) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range l {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		var value []byte
+		if f.Value.Kind() == slog.KindGroup {
+			value, err = encodingJSONFields(f.Value.Group()).MarshalJSON()
+		} else {
+			value, err = json.Marshal(f.Value.Any())
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func BenchmarkGologJSON(b *testing.B) {
+	logger := golog.New().SetOutput(nopOutput).SetLevel("debug")
+	logger.SetFormat("json", "")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		jsonGolog(logger, i)
+	}
+}
+
+func BenchmarkGologEncodingJSON(b *testing.B) {
+	logger := golog.New().SetOutput(nopOutput).SetLevel("debug")
+	logger.RegisterFormatter(new(encodingJSONFormatter))
+	logger.SetFormat("encoding/json")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		jsonGolog(logger, i)
+	}
+}
+
+func jsonGolog(logger *golog.Logger, i int) {
+	logger.Errorw("This is an error message", "iteration", i, "user", "kataras", "latency_ms", 12.5)
+	logger.Warnw("This is a warning message", "iteration", i, "user", "kataras", "latency_ms", 12.5)
+	logger.Infow("This is an info message", "iteration", i, "user", "kataras", "latency_ms", 12.5)
+}
+
+func BenchmarkLogrusJSON(b *testing.B) {
+	logger := logrus.New()
+	logger.SetOutput(nopOutput)
+	logger.SetFormatter(new(logrus.JSONFormatter))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		entry := logger.WithFields(logrus.Fields{"iteration": i, "user": "kataras", "latency_ms": 12.5})
+		entry.Error("This is an error message")
+		entry.Warn("This is a warning message")
+		entry.Info("This is an info message")
+	}
+}
+
+func BenchmarkStdJSON(b *testing.B) {
+	logger := slog.New(slog.NewJSONHandler(nopOutput, nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		logger.Error("This is an error message", "iteration", i, "user", "kataras", "latency_ms", 12.5)
+		logger.Warn("This is a warning message", "iteration", i, "user", "kataras", "latency_ms", 12.5)
+		logger.Info("This is an info message", "iteration", i, "user", "kataras", "latency_ms", 12.5)
+	}
+}
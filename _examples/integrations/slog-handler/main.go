@@ -0,0 +1,21 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/kataras/golog"
+)
+
+func main() {
+	golog.SetLevel("debug")
+
+	// Render the slog records through golog,
+	// with its levels, colors, outputs and formatters.
+	slog.SetDefault(slog.New(golog.NewSlogHandler(golog.Default)))
+
+	slog.Info("this info message is printed by golog", "service", "api")
+
+	logger := slog.Default().With("tenant", "acme").WithGroup("http")
+	logger.Debug("request", "method", "GET", "path", "/")
+	logger.Error("request failed", "status", 500)
+}
@@ -4,21 +4,18 @@ import (
 	"time"
 
 	"github.com/kataras/golog"
-
-	rotatelogs "github.com/lestrrat-go/file-rotatelogs"
 )
 
-/*
-	go get github.com/lestrrat-go/file-rotatelogs
-*/
-
 func main() {
-	// Read more at: https://github.com/lestrrat-go/file-rotatelogs#synopsis
-	pathToAccessLog := "./access_log.%Y%m%d%H%M"
-	w, err := rotatelogs.New(
-		pathToAccessLog,
-		rotatelogs.WithMaxAge(24*time.Hour),
-		rotatelogs.WithRotationTime(time.Hour))
+	// Writes to ./logs/access.log, rotates it hourly or when it reaches 10MB,
+	// keeps the last 24 rotated files, for a day at most, gzipped.
+	w, err := golog.NewFileOutput("./logs/access.log", golog.FileOptions{
+		MaxSize:    10 << 20,
+		Interval:   time.Hour,
+		MaxBackups: 24,
+		MaxAge:     24 * time.Hour,
+		Compress:   true,
+	})
 	if err != nil {
 		golog.Fatal(err)
 	}
@@ -27,4 +24,11 @@ func main() {
 	golog.SetOutput(w)
 
 	golog.Println("A Log entry")
+
+	// Rotate on demand, e.g. on SIGHUP.
+	if err = w.Rotate(); err != nil {
+		golog.Error(err)
+	}
+
+	golog.Println("Another Log entry")
 }
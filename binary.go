@@ -0,0 +1,337 @@
+package golog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// binaryEncoding is implemented by the binary formats, CBOR and MessagePack,
+// which share the same record layout:
+//
+//	{"time": time, "level": "info", "message": "...", "fields": {...},
+//	"caller": {"function": "...", "source": "...", "file": "...", "line": 1},
+//	"stacktrace": [{"function": "...", "source": "..."}]}
+//
+// The fields keep their order, groups are encoded as nested maps and
+// errors as maps of their "message", "type" and "causes".
+type binaryEncoding interface {
+	appendMap(buf []byte, n int) []byte
+	appendArray(buf []byte, n int) []byte
+	appendString(buf []byte, s string) []byte
+	appendBytes(buf []byte, b []byte) []byte
+	appendInt(buf []byte, n int64) []byte
+	appendUint(buf []byte, n uint64) []byte
+	appendFloat(buf []byte, f float64) []byte
+	appendBool(buf []byte, b bool) []byte
+	appendNil(buf []byte) []byte
+	appendTime(buf []byte, t time.Time) []byte
+}
+
+// appendBinaryLog appends the record of the "log" through the "enc" encoding.
+func appendBinaryLog(enc binaryEncoding, buf []byte, log *Log) []byte {
+	n := 3
+	if len(log.Fields) > 0 {
+		n++
+	}
+	if !log.Caller.IsZero() {
+		n++
+	}
+	if len(log.Stacktrace) > 0 {
+		n++
+	}
+
+	buf = enc.appendMap(buf, n)
+	buf = enc.appendString(buf, "time")
+	buf = enc.appendTime(buf, log.Time)
+	buf = enc.appendString(buf, "level")
+	buf = enc.appendString(buf, log.Level.String())
+	buf = enc.appendString(buf, "message")
+	buf = enc.appendString(buf, log.Message)
+
+	if len(log.Fields) > 0 {
+		buf = enc.appendString(buf, "fields")
+		buf = appendBinaryFields(enc, buf, log.Fields)
+	}
+
+	if !log.Caller.IsZero() {
+		buf = enc.appendString(buf, "caller")
+		buf = enc.appendMap(buf, 4)
+		buf = enc.appendString(buf, "function")
+		buf = enc.appendString(buf, log.Caller.Function)
+		buf = enc.appendString(buf, "source")
+		buf = enc.appendString(buf, log.Caller.Source)
+		buf = enc.appendString(buf, "file")
+		buf = enc.appendString(buf, log.Caller.File)
+		buf = enc.appendString(buf, "line")
+		buf = enc.appendInt(buf, int64(log.Caller.Line))
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = enc.appendString(buf, "stacktrace")
+		buf = enc.appendArray(buf, len(log.Stacktrace))
+		for _, frame := range log.Stacktrace {
+			buf = enc.appendMap(buf, 2)
+			buf = enc.appendString(buf, "function")
+			buf = enc.appendString(buf, frame.Function)
+			buf = enc.appendString(buf, "source")
+			buf = enc.appendString(buf, frame.Source)
+		}
+	}
+
+	return buf
+}
+
+func appendBinaryFields(enc binaryEncoding, buf []byte, fields []Field) []byte {
+	buf = enc.appendMap(buf, len(fields))
+	for _, field := range fields {
+		buf = enc.appendString(buf, field.Key)
+		buf = appendBinaryValue(enc, buf, field.Value)
+	}
+
+	return buf
+}
+
+func appendBinaryValue(enc binaryEncoding, buf []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return enc.appendString(buf, v.String())
+	case slog.KindInt64:
+		return enc.appendInt(buf, v.Int64())
+	case slog.KindUint64:
+		return enc.appendUint(buf, v.Uint64())
+	case slog.KindFloat64:
+		return enc.appendFloat(buf, v.Float64())
+	case slog.KindBool:
+		return enc.appendBool(buf, v.Bool())
+	case slog.KindDuration:
+		return enc.appendInt(buf, int64(v.Duration()))
+	case slog.KindTime:
+		return enc.appendTime(buf, v.Time())
+	case slog.KindGroup:
+		return appendBinaryFields(enc, buf, v.Group())
+	}
+
+	switch value := v.Any().(type) {
+	case nil:
+		return enc.appendNil(buf)
+	case *ErrorInfo:
+		return appendBinaryError(enc, buf, value)
+	case error:
+		return enc.appendString(buf, value.Error())
+	case []byte:
+		return enc.appendBytes(buf, value)
+	case []string:
+		buf = enc.appendArray(buf, len(value))
+		for _, s := range value {
+			buf = enc.appendString(buf, s)
+		}
+		return buf
+	case []int:
+		buf = enc.appendArray(buf, len(value))
+		for _, n := range value {
+			buf = enc.appendInt(buf, int64(n))
+		}
+		return buf
+	case []float64:
+		buf = enc.appendArray(buf, len(value))
+		for _, f := range value {
+			buf = enc.appendFloat(buf, f)
+		}
+		return buf
+	case []any:
+		buf = enc.appendArray(buf, len(value))
+		for _, elem := range value {
+			buf = appendBinaryValue(enc, buf, fieldValue(elem))
+		}
+		return buf
+	default:
+		return enc.appendString(buf, v.String())
+	}
+}
+
+func appendBinaryError(enc binaryEncoding, buf []byte, info *ErrorInfo) []byte {
+	n := 2
+	if len(info.Causes) > 0 {
+		n++
+	}
+
+	buf = enc.appendMap(buf, n)
+	buf = enc.appendString(buf, "message")
+	buf = enc.appendString(buf, info.Message)
+	buf = enc.appendString(buf, "type")
+	buf = enc.appendString(buf, info.Type)
+
+	if len(info.Causes) > 0 {
+		buf = enc.appendString(buf, "causes")
+		buf = enc.appendArray(buf, len(info.Causes))
+		for _, cause := range info.Causes {
+			buf = appendBinaryError(enc, buf, cause)
+		}
+	}
+
+	return buf
+}
+
+// binaryDecoder is implemented by the decoders of the binary formats.
+// Maps are decoded as groups, keeping their order,
+// and arrays as []slog.Value values.
+type binaryDecoder interface {
+	decodeValue(r *bufio.Reader, depth int) (slog.Value, error)
+}
+
+// maxBinaryDepth is the maximum nesting of the decoded values.
+const maxBinaryDepth = 64
+
+var errBinaryDepth = errors.New("golog: binary: maximum nesting depth exceeded")
+
+// decodeBinaryLogs decodes all the records of "r" through the "dec" decoder.
+func decodeBinaryLogs(dec binaryDecoder, r io.Reader) ([]Log, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	var logs []Log
+	for {
+		if _, err := br.Peek(1); err != nil {
+			if err == io.EOF {
+				return logs, nil
+			}
+			return logs, err
+		}
+
+		v, err := dec.decodeValue(br, 0)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return logs, err
+		}
+
+		log, err := binaryLog(v)
+		if err != nil {
+			return logs, err
+		}
+		logs = append(logs, log)
+	}
+}
+
+// binaryLog converts a decoded record to a Log.
+func binaryLog(v slog.Value) (Log, error) {
+	if v.Kind() != slog.KindGroup {
+		return Log{}, fmt.Errorf("golog: binary: record is not a map: %s", v.Kind())
+	}
+
+	var log Log
+	for _, attr := range v.Group() {
+		switch attr.Key {
+		case "time":
+			switch attr.Value.Kind() {
+			case slog.KindTime:
+				log.Time = attr.Value.Time()
+			case slog.KindInt64:
+				log.Time = time.Unix(0, attr.Value.Int64())
+			}
+			log.Timestamp = log.Time.Unix()
+		case "level":
+			log.Level = ParseLevel(attr.Value.String())
+		case "message":
+			log.Message = attr.Value.String()
+		case "fields":
+			if attr.Value.Kind() == slog.KindGroup {
+				log.Fields = binaryFields(attr.Value.Group())
+			}
+		case "caller":
+			log.Caller = binaryFrame(attr.Value)
+		case "stacktrace":
+			if values, ok := attr.Value.Any().([]slog.Value); ok {
+				for _, value := range values {
+					log.Stacktrace = append(log.Stacktrace, binaryFrame(value))
+				}
+			}
+		}
+	}
+
+	log.NewLine = true
+	return log, nil
+}
+
+// binaryFields converts the decoded arrays of the "fields" to []any values.
+func binaryFields(fields []Field) FieldList {
+	list := make(FieldList, 0, len(fields))
+	for _, field := range fields {
+		switch field.Value.Kind() {
+		case slog.KindGroup:
+			field.Value = slog.GroupValue(binaryFields(field.Value.Group())...)
+		case slog.KindAny:
+			if values, ok := field.Value.Any().([]slog.Value); ok {
+				field.Value = slog.AnyValue(binaryArray(values))
+			}
+		}
+
+		list = append(list, field)
+	}
+
+	return list
+}
+
+func binaryArray(values []slog.Value) []any {
+	array := make([]any, len(values))
+	for i, value := range values {
+		switch value.Kind() {
+		case slog.KindGroup:
+			array[i] = binaryFields(value.Group()).Map()
+		case slog.KindAny:
+			if nested, ok := value.Any().([]slog.Value); ok {
+				array[i] = binaryArray(nested)
+				continue
+			}
+			array[i] = value.Any()
+		default:
+			array[i] = value.Any()
+		}
+	}
+
+	return array
+}
+
+func binaryFrame(v slog.Value) Frame {
+	var frame Frame
+	if v.Kind() != slog.KindGroup {
+		return frame
+	}
+
+	for _, attr := range v.Group() {
+		switch attr.Key {
+		case "function":
+			frame.Function = attr.Value.String()
+		case "source":
+			frame.Source = attr.Value.String()
+		case "file":
+			frame.File = attr.Value.String()
+		case "line":
+			if attr.Value.Kind() == slog.KindInt64 {
+				frame.Line = int(attr.Value.Int64())
+			} else if attr.Value.Kind() == slog.KindUint64 {
+				frame.Line = int(attr.Value.Uint64())
+			}
+		}
+	}
+
+	return frame
+}
+
+// readBinaryBytes reads "n" bytes of "r".
+func readBinaryBytes(r *bufio.Reader, n uint64) ([]byte, error) {
+	if n > 1<<30 {
+		return nil, fmt.Errorf("golog: binary: length %d too large", n)
+	}
+
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
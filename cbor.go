@@ -0,0 +1,291 @@
+package golog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// CBORFormatter is a Formatter type for CBOR (RFC 8949) logs,
+// a compact binary encoding for high-volume pipelines.
+// Each log is encoded as a map of its time, level, message, fields, caller and stacktrace,
+// the records are written one after the other. Times are encoded as
+// RFC 3339 strings (tag 0), with nanosecond precision.
+//
+// Use `DecodeCBOR` to read the logs back.
+type CBORFormatter struct{}
+
+// String returns the name of the Formatter.
+// In this case it returns "cbor".
+// It's used to map the formatter names with their implementations.
+func (f *CBORFormatter) String() string {
+	return "cbor"
+}
+
+// Options returns a new CBOR Formatter, it accepts no options.
+func (f *CBORFormatter) Options(opts ...any) Formatter {
+	return new(CBORFormatter)
+}
+
+// Format prints the logs in CBOR format.
+//
+// Usage:
+// logger.SetFormat("cbor") or
+// logger.SetLevelFormat("info", "cbor")
+func (f *CBORFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	*bufPtr = appendBinaryLog(cborEncoding{}, *bufPtr, log)
+	_, err := dest.Write(*bufPtr)
+	return err == nil
+}
+
+// DecodeCBOR decodes the logs written by the "cbor" formatter.
+// The decoded logs have no `Logger`, errors are decoded as groups
+// of their "message", "type" and "causes" and durations as nanoseconds.
+func DecodeCBOR(r io.Reader) ([]Log, error) {
+	return decodeBinaryLogs(cborEncoding{}, r)
+}
+
+// The CBOR major types.
+const (
+	cborUint = iota << 5
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+type cborEncoding struct{}
+
+func (cborEncoding) appendHead(buf []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(buf, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+	}
+}
+
+func (e cborEncoding) appendMap(buf []byte, n int) []byte {
+	return e.appendHead(buf, cborMap, uint64(n))
+}
+
+func (e cborEncoding) appendArray(buf []byte, n int) []byte {
+	return e.appendHead(buf, cborArray, uint64(n))
+}
+
+func (e cborEncoding) appendString(buf []byte, s string) []byte {
+	return append(e.appendHead(buf, cborText, uint64(len(s))), s...)
+}
+
+func (e cborEncoding) appendBytes(buf []byte, b []byte) []byte {
+	return append(e.appendHead(buf, cborBytes, uint64(len(b))), b...)
+}
+
+func (e cborEncoding) appendInt(buf []byte, n int64) []byte {
+	if n < 0 {
+		return e.appendHead(buf, cborNegInt, uint64(-1-n))
+	}
+
+	return e.appendHead(buf, cborUint, uint64(n))
+}
+
+func (e cborEncoding) appendUint(buf []byte, n uint64) []byte {
+	return e.appendHead(buf, cborUint, n)
+}
+
+func (cborEncoding) appendFloat(buf []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(append(buf, cborSimple|27), math.Float64bits(f))
+}
+
+func (cborEncoding) appendBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, cborSimple|21)
+	}
+
+	return append(buf, cborSimple|20)
+}
+
+func (cborEncoding) appendNil(buf []byte) []byte {
+	return append(buf, cborSimple|22)
+}
+
+func (e cborEncoding) appendTime(buf []byte, t time.Time) []byte {
+	buf = append(buf, cborTag|0) // standard date/time string.
+	return e.appendString(buf, t.Format(time.RFC3339Nano))
+}
+
+func (e cborEncoding) decodeValue(r *bufio.Reader, depth int) (slog.Value, error) {
+	if depth > maxBinaryDepth {
+		return slog.Value{}, errBinaryDepth
+	}
+
+	initial, err := r.ReadByte()
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	major, info := initial&0xe0, initial&0x1f
+	if major == cborSimple {
+		return e.decodeSimple(r, info)
+	}
+
+	n, err := e.readArgument(r, info)
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	switch major {
+	case cborUint:
+		if n > math.MaxInt64 {
+			return slog.Uint64Value(n), nil
+		}
+		return slog.Int64Value(int64(n)), nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return slog.Value{}, fmt.Errorf("golog: cbor: negative integer overflows int64")
+		}
+		return slog.Int64Value(-1 - int64(n)), nil
+	case cborBytes:
+		b, err := readBinaryBytes(r, n)
+		return slog.AnyValue(b), err
+	case cborText:
+		b, err := readBinaryBytes(r, n)
+		return slog.StringValue(string(b)), err
+	case cborArray:
+		values := make([]slog.Value, 0, min(n, 1024))
+		for range n {
+			v, err := e.decodeValue(r, depth+1)
+			if err != nil {
+				return slog.Value{}, err
+			}
+			values = append(values, v)
+		}
+		return slog.AnyValue(values), nil
+	case cborMap:
+		attrs := make([]slog.Attr, 0, min(n, 1024))
+		for range n {
+			key, err := e.decodeValue(r, depth+1)
+			if err != nil {
+				return slog.Value{}, err
+			}
+
+			v, err := e.decodeValue(r, depth+1)
+			if err != nil {
+				return slog.Value{}, err
+			}
+			attrs = append(attrs, slog.Attr{Key: key.String(), Value: v})
+		}
+		return slog.GroupValue(attrs...), nil
+	default: // tag.
+		v, err := e.decodeValue(r, depth+1)
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		switch {
+		case n == 0 && v.Kind() == slog.KindString: // date/time string.
+			t, err := time.Parse(time.RFC3339Nano, v.String())
+			if err != nil {
+				return slog.Value{}, fmt.Errorf("golog: cbor: %w", err)
+			}
+			return slog.TimeValue(t), nil
+		case n == 1 && v.Kind() == slog.KindInt64: // epoch seconds.
+			return slog.TimeValue(time.Unix(v.Int64(), 0)), nil
+		case n == 1 && v.Kind() == slog.KindFloat64:
+			sec, frac := math.Modf(v.Float64())
+			return slog.TimeValue(time.Unix(int64(sec), int64(frac*1e9))), nil
+		default: // unknown tags are ignored.
+			return v, nil
+		}
+	}
+}
+
+// readArgument reads the argument of a data item's head, based on its additional information.
+func (cborEncoding) readArgument(r *bufio.Reader, info byte) (uint64, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("golog: cbor: unsupported additional information %d", info)
+	}
+
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[8-size:]); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+func (e cborEncoding) decodeSimple(r *bufio.Reader, info byte) (slog.Value, error) {
+	switch info {
+	case 20:
+		return slog.BoolValue(false), nil
+	case 21:
+		return slog.BoolValue(true), nil
+	case 22, 23: // null, undefined.
+		return slog.AnyValue(nil), nil
+	case 25, 26, 27:
+		n, err := e.readArgument(r, info)
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		switch info {
+		case 25:
+			return slog.Float64Value(halfToFloat64(uint16(n))), nil
+		case 26:
+			return slog.Float64Value(float64(math.Float32frombits(uint32(n)))), nil
+		default:
+			return slog.Float64Value(math.Float64frombits(n)), nil
+		}
+	default:
+		return slog.Value{}, fmt.Errorf("golog: cbor: unsupported simple value %d", info)
+	}
+}
+
+// halfToFloat64 converts an IEEE 754 half-precision float.
+func halfToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+
+	exp, mant := int(h>>10)&0x1f, float64(h&0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	default:
+		return sign * math.Ldexp(mant+1024, exp-25)
+	}
+}
@@ -0,0 +1,30 @@
+package golog
+
+import "context"
+
+// ContextExtractor is the signature type of a function
+// which pulls fields out of a context, e.g. request, user or trace IDs.
+//
+// See `Logger.AddContextExtractor` for more.
+type ContextExtractor func(ctx context.Context) Fields
+
+type loggerContextKey struct{}
+
+// NewContext returns a copy of the "ctx" which carries the given "logger".
+// Use `FromContext` to retrieve it.
+func NewContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the Logger stored in the "ctx" by `NewContext`.
+// If no Logger is stored then it returns the package-level `Default` one.
+// It does NOT return nil.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return Default
+}
@@ -0,0 +1,335 @@
+package golog
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kataras/golog/printer"
+)
+
+// DefaultCSVColumns are the columns of the CSV Formatter when none are declared.
+var DefaultCSVColumns = []string{"time", "level", "prefix", "message", "fields"}
+
+// CSVOptions holds the options of the CSV Formatter.
+//
+// The available columns are:
+//   - "time", formatted by the TimeFormat
+//   - "level", the level's name
+//   - "prefix", the Logger's prefix
+//   - "message"
+//   - "caller", the caller's source
+//   - "stacktrace", the frames in the format of a Go panic's stack
+//   - "fields", all the fields as a JSON object
+//   - "fields.<key>", the value of a field, nested fields are separated by a dot,
+//     e.g. "fields.http.method". A column of any other name is a field's key too.
+type CSVOptions struct {
+	// Columns is the ordered list of the columns, defaults to the `DefaultCSVColumns`.
+	Columns []string
+	// Header writes the column names as the first record
+	// of an output which has no records yet, e.g. an empty file.
+	Header bool
+	// TimeFormat is the layout of the time column, defaults to `time.RFC3339Nano`.
+	TimeFormat string
+}
+
+// CSVFormatter is a Formatter type for CSV (RFC 4180) and TSV logs,
+// one record per log, with a declared column schema, e.g.
+//
+//	logger.SetFormat("csv", "time,level,prefix,message,fields.user_id,fields.latency_ms", true)
+//
+// Values which contain the separator, quotes or line breaks are quoted,
+// with their quotes doubled. Fields which don't exist are written as empty values.
+type CSVFormatter struct {
+	// Comma is the values separator, defaults to ','.
+	// The "tsv" formatter uses '\t'.
+	Comma rune
+	// Config holds the columns, the header and the time format.
+	Config CSVOptions
+
+	mu      sync.Mutex
+	outputs map[io.Writer]struct{} // the outputs which the header, or a record, was written to.
+}
+
+// String returns the name of the Formatter.
+// In this case it returns "csv", or "tsv" when its Comma is a tab.
+// It's used to map the formatter names with their implementations.
+func (f *CSVFormatter) String() string {
+	if f.Comma == '\t' {
+		return "tsv"
+	}
+
+	return "csv"
+}
+
+// Options sets the options of the CSV Formatter and returns a new one.
+// Accepts a `CSVOptions`, a string or a []string of the columns
+// (a string is split by commas) and a bool to write the header,
+// e.g. logger.SetFormat("csv", "time,level,message,fields.user_id", true).
+func (f *CSVFormatter) Options(opts ...any) Formatter {
+	formatter := &CSVFormatter{
+		Comma:  f.Comma,
+		Config: f.Config,
+	}
+
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case CSVOptions:
+			formatter.Config = v
+		case string:
+			columns := strings.Split(v, ",")
+			for i, column := range columns {
+				columns[i] = strings.TrimSpace(column)
+			}
+			formatter.Config.Columns = columns
+		case []string:
+			formatter.Config.Columns = v
+		case bool:
+			formatter.Config.Header = v
+		}
+	}
+
+	return formatter
+}
+
+// Format prints the logs in CSV format.
+//
+// Usage:
+// logger.SetFormat("csv", "time,level,message,fields.user_id", true) or
+// logger.SetLevelFormat("info", "tsv")
+func (f *CSVFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	columns := f.Config.Columns
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+
+	buf := *bufPtr
+	if f.Config.Header {
+		for i, column := range columns {
+			if i > 0 {
+				buf = utf8.AppendRune(buf, f.comma())
+			}
+			buf = f.appendValue(buf, column)
+		}
+		buf = append(buf, '\n')
+	}
+	headerLen := len(buf)
+
+	for i, column := range columns {
+		if i > 0 {
+			buf = utf8.AppendRune(buf, f.comma())
+		}
+		buf = f.appendValue(buf, f.columnValue(log, column))
+	}
+	buf = append(buf, '\n')
+	*bufPtr = buf
+
+	if !f.Config.Header {
+		_, err := dest.Write(buf)
+		return err == nil
+	}
+
+	record := func(w io.Writer) []byte {
+		if f.needsHeader(w) {
+			return buf
+		}
+		return buf[headerLen:]
+	}
+
+	var err error
+	if p, ok := dest.(*printer.Printer); ok { // the header is written to its outputs which need it.
+		_, err = p.WriteEach(record)
+	} else {
+		_, err = dest.Write(record(dest))
+	}
+
+	return err == nil
+}
+
+func (f *CSVFormatter) comma() rune {
+	if f.Comma == 0 {
+		return ','
+	}
+
+	return f.Comma
+}
+
+// needsHeader reports whether the header should be written to the "w" output,
+// i.e. it's the first record of this formatter written to it and it has no contents.
+// An output with a `Size() int64` method, e.g. the `FileOutput`, needs it whenever it's empty,
+// e.g. after a `FileOutput.Rotate` call. Outputs which can't be compared,
+// and so can't be tracked, get it only when they report their size.
+func (f *CSVFormatter) needsHeader(w io.Writer) bool {
+	if sized, ok := w.(interface{ Size() int64 }); ok {
+		return sized.Size() == 0
+	}
+
+	if typ := reflect.TypeOf(w); typ == nil || !typ.Comparable() {
+		return false
+	}
+
+	f.mu.Lock()
+	_, written := f.outputs[w]
+	if !written {
+		if f.outputs == nil {
+			f.outputs = make(map[io.Writer]struct{})
+		}
+		f.outputs[w] = struct{}{}
+	}
+	f.mu.Unlock()
+
+	if written {
+		return false
+	}
+
+	if file, ok := w.(interface{ Stat() (os.FileInfo, error) }); ok {
+		if info, err := file.Stat(); err == nil && info.Mode().IsRegular() && info.Size() > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keepOutputs forgets the outputs which are not in the "outputs" list,
+// it's called when the Logger's outputs change.
+func (f *CSVFormatter) keepOutputs(outputs []io.Writer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for w := range f.outputs {
+		if !slices.Contains(outputs, w) {
+			delete(f.outputs, w)
+		}
+	}
+}
+
+// columnValue returns the value of the "column" of the log.
+func (f *CSVFormatter) columnValue(log *Log, column string) string {
+	switch column {
+	case "time":
+		layout := f.Config.TimeFormat
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		return log.Time.Format(layout)
+	case "level":
+		return log.Level.String()
+	case "prefix":
+		return log.Prefix()
+	case "message":
+		return log.Message
+	case "caller":
+		return log.Caller.Source
+	case "stacktrace":
+		return stacktraceString(log.Stacktrace)
+	case "fields":
+		if len(log.Fields) == 0 {
+			return ""
+		}
+		return string(appendJSONFields(nil, log.Fields))
+	}
+
+	v, ok := lookupField(log.Fields, strings.TrimPrefix(column, "fields."))
+	if !ok {
+		return ""
+	}
+
+	return fieldString(v)
+}
+
+// lookupField returns the value of the field of the given dotted "path",
+// a key which contains dots itself is matched too.
+func lookupField(fields []Field, path string) (slog.Value, bool) {
+	for _, field := range fields {
+		if field.Key == path {
+			return field.Value, true
+		}
+
+		rest, ok := strings.CutPrefix(path, field.Key+".")
+		if ok && field.Value.Kind() == slog.KindGroup {
+			if v, found := lookupField(field.Value.Group(), rest); found {
+				return v, true
+			}
+		}
+	}
+
+	return slog.Value{}, false
+}
+
+// fieldString returns the text of a field's value, groups and lists are written as JSON.
+func fieldString(v slog.Value) string {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return strconv.FormatInt(v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.FormatUint(v.Uint64(), 10)
+	case slog.KindFloat64:
+		return strconv.FormatFloat(v.Float64(), 'g', -1, 64)
+	case slog.KindBool:
+		return strconv.FormatBool(v.Bool())
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindGroup:
+		return string(appendJSONFields(nil, v.Group()))
+	}
+
+	switch value := v.Any().(type) {
+	case nil:
+		return ""
+	case *ErrorInfo:
+		return value.Message
+	case error:
+		return value.Error()
+	default:
+		return string(appendJSONAny(nil, value))
+	}
+}
+
+// appendValue appends the "s" value, quoted when it's needed.
+func (f *CSVFormatter) appendValue(buf []byte, s string) []byte {
+	if !f.needsQuotes(s) {
+		return append(buf, s...)
+	}
+
+	buf = append(buf, '"')
+	for {
+		i := strings.IndexByte(s, '"')
+		if i < 0 {
+			break
+		}
+		buf = append(buf, s[:i+1]...)
+		buf = append(buf, '"')
+		s = s[i+1:]
+	}
+	buf = append(buf, s...)
+	return append(buf, '"')
+}
+
+// needsQuotes reports whether the "s" value should be quoted, like the `encoding/csv` package does:
+// it contains the separator, quotes or line breaks or it begins with a space.
+func (f *CSVFormatter) needsQuotes(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	if s == `\.` || s[0] == ' ' || s[0] == '\t' {
+		return true
+	}
+
+	return strings.ContainsRune(s, f.comma()) || strings.ContainsAny(s, "\"\r\n")
+}
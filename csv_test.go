@@ -0,0 +1,88 @@
+package golog
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCSVFormatterHeader(t *testing.T) {
+	var first, second bytes.Buffer
+	logger := New().SetOutput(&first)
+	logger.SetFormat("csv", "level,message,fields.user_id", true)
+
+	logger.Infow("one", "user_id", 1)
+	logger.AddOutput(&second)
+	logger.Warnw("two, quoted", "user_id", 2)
+
+	if expected, got := "level,message,fields.user_id\ninfo,one,1\nwarn,\"two, quoted\",2\n", first.String(); got != expected {
+		t.Fatalf("expected the header once on the first output:\n%q\nbut got:\n%q", expected, got)
+	}
+
+	if expected, got := "level,message,fields.user_id\nwarn,\"two, quoted\",2\n", second.String(); got != expected {
+		t.Fatalf("expected the header on the added output:\n%q\nbut got:\n%q", expected, got)
+	}
+
+	// the replaced outputs are forgotten.
+	logger.SetOutput(&second)
+	first.Reset()
+	logger.SetOutput(&first)
+	logger.Info("three")
+
+	if expected, got := "level,message,fields.user_id\ninfo,three,\n", first.String(); got != expected {
+		t.Fatalf("expected the header on the output set again:\n%q\nbut got:\n%q", expected, got)
+	}
+
+	if n := len(logger.formatter.(*CSVFormatter).outputs); n != 1 {
+		t.Fatalf("expected the formatter to track 1 output but got %d", n)
+	}
+}
+
+func TestCSVFormatterFileOutput(t *testing.T) {
+	output, err := NewFileOutput(filepath.Join(t.TempDir(), "app.csv"), FileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	logger := New().SetOutput(output).SetFormat("tsv", "level,message", true)
+	logger.Info("one")
+	if err = output.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("two")
+
+	if expected, got := "level\tmessage\ninfo\ttwo\n", readFile(t, output.name); got != expected {
+		t.Fatalf("expected a header after the rotation:\n%q\nbut got:\n%q", expected, got)
+	}
+}
+
+func TestCSVFormatterConcurrent(t *testing.T) {
+	var first, second bytes.Buffer
+	logger := New().SetOutput(&first).AddOutput(&second)
+	logger.SetFormat("csv", "level,message", true)
+
+	const n = 100
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(func() {
+			logger.Info("message")
+		})
+	}
+	wg.Wait()
+
+	for _, buf := range []*bytes.Buffer{&first, &second} {
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != n+1 || lines[0] != "level,message" {
+			t.Fatalf("expected the header and %d records but got %d lines", n, len(lines))
+		}
+
+		for _, line := range lines[1:] {
+			if line != "info,message" {
+				t.Fatalf("unexpected record: %q", line)
+			}
+		}
+	}
+}
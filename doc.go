@@ -38,7 +38,7 @@ Source code and other details for the project are available at GitHub:
 
 # Current Version
 
-0.1.14
+0.2.0
 
 # Installation
 
@@ -365,4 +365,4 @@ Examples:
 package golog
 
 // Version is the version string representation of the "golog" package.
-const Version = "0.1.12"
+const Version = "0.2.0"
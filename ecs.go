@@ -0,0 +1,218 @@
+package golog
+
+import (
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ECSVersion is the Elastic Common Schema version the "ecs" formatter conforms to.
+const ECSVersion = "8.11.0"
+
+// ECSFormatter is a Formatter type for Elastic Common Schema (ECS) JSON logs,
+// which can be shipped to Elasticsearch as they are, e.g.
+//
+//	{"@timestamp":"2025-08-24T18:15:04.123456Z","log.level":"error","message":"query failed","ecs.version":"8.11.0",
+//	"log":{"logger":"db","origin":{"file":{"name":"main.go","line":29},"function":"main.main"}},
+//	"error":{"message":"connection refused","type":"*net.OpError"},"labels":{"table":"users"}}
+//
+// The `log.logger` is the Logger's prefix, the `log.origin` is the `Log.Caller`
+// or the first frame of the `Log.Stacktrace`, which is written to the `error.stack_trace`.
+// The first error field is written to the `error` object
+// and the remaining fields under the `labels` object, with their nested keys joined by an underscore
+// and their values written as strings, as ECS labels are keyword-only.
+// See `TopLevelFields` too.
+type ECSFormatter struct {
+	// TopLevelFields writes the fields at the top level of the document, instead of under `labels`.
+	// Fields of the ECS keys which are written by the formatter itself are kept under `labels`.
+	TopLevelFields bool
+}
+
+// String returns the name of the Formatter.
+// In this case it returns "ecs".
+// It's used to map the formatter names with their implementations.
+func (f *ECSFormatter) String() string {
+	return "ecs"
+}
+
+// Options sets the options for the ECS Formatter and returns a new one.
+// A bool option sets the `TopLevelFields`.
+func (f *ECSFormatter) Options(opts ...any) Formatter {
+	formatter := &ECSFormatter{TopLevelFields: f.TopLevelFields}
+	for _, opt := range opts {
+		if topLevel, ok := opt.(bool); ok {
+			formatter.TopLevelFields = topLevel
+		}
+	}
+
+	return formatter
+}
+
+// ecsKeys are the top-level keys written by the ECS formatter.
+var ecsKeys = map[string]struct{}{
+	"@timestamp": {}, "log": {}, "message": {}, "ecs": {}, "error": {}, "labels": {},
+}
+
+// Format prints the logs in ECS JSON format.
+//
+// Usage:
+// logger.SetFormat("ecs") or
+// logger.SetLevelFormat("error", "ecs")
+func (f *ECSFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	buf := append(*bufPtr, `{"@timestamp":`...)
+	buf = appendJSON(buf, log.Time.UTC().Format(time.RFC3339Nano))
+
+	if log.Level != DisableLevel {
+		buf = append(buf, `,"log.level":`...)
+		buf = appendJSON(buf, log.Level.String())
+	}
+
+	buf = append(buf, `,"message":`...)
+	buf = appendJSON(buf, log.Message)
+	buf = append(buf, `,"ecs.version":"`+ECSVersion+`"`...)
+
+	buf = appendECSLog(buf, log)
+
+	var (
+		errField  *ErrorInfo
+		labels    FieldList
+		topFields FieldList
+	)
+
+	for _, field := range log.Fields {
+		if info, ok := field.Value.Any().(*ErrorInfo); ok && errField == nil {
+			errField = info
+			continue
+		}
+
+		if _, reserved := ecsKeys[field.Key]; f.TopLevelFields && !reserved {
+			topFields = append(topFields, field)
+		} else {
+			labels = append(labels, field)
+		}
+	}
+
+	buf = appendECSError(buf, errField, log.Stacktrace)
+
+	if len(labels) > 0 {
+		buf = append(buf, `,"labels":{`...)
+		buf = appendECSLabels(buf, "", labels, true)
+		buf = append(buf, '}')
+	}
+
+	for _, field := range topFields {
+		buf = append(buf, ',')
+		buf = appendJSON(buf, field.Key)
+		buf = append(buf, ':')
+		buf = appendJSONValue(buf, field.Value)
+	}
+
+	buf = append(buf, "}\n"...)
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+// appendECSLog appends the "log" object of the logger's name and the origin frame.
+func appendECSLog(buf []byte, log *Log) []byte {
+	logger := log.Prefix()
+
+	origin := log.Caller
+	if origin.IsZero() && len(log.Stacktrace) > 0 {
+		origin = log.Stacktrace[0]
+	}
+
+	if logger == "" && origin.IsZero() {
+		return buf
+	}
+
+	buf = append(buf, `,"log":{`...)
+	if logger != "" {
+		buf = append(buf, `"logger":`...)
+		buf = appendJSON(buf, logger)
+		if !origin.IsZero() {
+			buf = append(buf, ',')
+		}
+	}
+
+	if !origin.IsZero() {
+		file, line := origin.File, origin.Line
+		if file == "" { // a frame of a custom handler, parse its source.
+			if i := strings.LastIndexByte(origin.Source, ':'); i > 0 {
+				file = origin.Source[:i]
+				line, _ = strconv.Atoi(origin.Source[i+1:])
+			}
+		}
+
+		buf = append(buf, `"origin":{"file":{"name":`...)
+		buf = appendJSON(buf, filepath.Base(file))
+		if line > 0 {
+			buf = append(buf, `,"line":`...)
+			buf = strconv.AppendInt(buf, int64(line), 10)
+		}
+		buf = append(buf, '}')
+
+		if origin.Function != "" {
+			buf = append(buf, `,"function":`...)
+			buf = appendJSON(buf, origin.Function)
+		}
+		buf = append(buf, '}')
+	}
+
+	return append(buf, '}')
+}
+
+// appendECSError appends the "error" object of the error field and the stacktrace.
+func appendECSError(buf []byte, info *ErrorInfo, stacktrace []Frame) []byte {
+	if info == nil && len(stacktrace) == 0 {
+		return buf
+	}
+
+	buf = append(buf, `,"error":{`...)
+	if info != nil {
+		buf = append(buf, `"message":`...)
+		buf = appendJSON(buf, info.Message)
+		buf = append(buf, `,"type":`...)
+		buf = appendJSON(buf, info.Type)
+		if len(stacktrace) > 0 {
+			buf = append(buf, ',')
+		}
+	}
+
+	if len(stacktrace) > 0 {
+		buf = append(buf, `"stack_trace":`...)
+		buf = appendJSON(buf, stacktraceString(stacktrace))
+	}
+
+	return append(buf, '}')
+}
+
+// appendECSLabels appends the "fields" as flat, keyword labels: nested keys are joined
+// by an underscore as ECS labels can't contain dots and the values are written as strings.
+func appendECSLabels(buf []byte, prefix string, fields FieldList, first bool) []byte {
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			buf = appendECSLabels(buf, prefix+field.Key+"_", field.Value.Group(), first)
+			first = false
+			continue
+		}
+
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+
+		buf = appendJSON(buf, strings.ReplaceAll(prefix+field.Key, ".", "_"))
+		buf = append(buf, ':')
+		buf = appendJSONString(buf, fieldString(field.Value))
+	}
+
+	return buf
+}
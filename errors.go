@@ -0,0 +1,138 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// maxErrorDepth limits the depth of the causes tree of an `ErrorInfo`.
+const maxErrorDepth = 32
+
+// ErrorInfo is the structured representation of an error value.
+// Errors passed among the log methods' arguments, the `Err` field
+// and any field of an error value are recorded as ErrorInfo,
+// it's encoded as a nested object by the JSON formatter
+// and as an indented cause list by the text output.
+//
+// ErrorInfo implements the error interface itself
+// and it unwraps to the original error.
+type ErrorInfo struct {
+	// Message is the error's message.
+	Message string `json:"message"`
+	// Type is the concrete type of the error, e.g. "*fs.PathError".
+	Type string `json:"type"`
+	// Causes are the errors wrapped by this error,
+	// through `errors.Unwrap` or `errors.Join`.
+	Causes []*ErrorInfo `json:"causes,omitempty"`
+	// Err is the original error.
+	Err error `json:"-"`
+}
+
+// NewErrorInfo returns the structured representation of the "err" error,
+// including its full cause tree. It returns nil if "err" is nil.
+func NewErrorInfo(err error) *ErrorInfo {
+	return newErrorInfo(err, 0)
+}
+
+func newErrorInfo(err error, depth int) *ErrorInfo {
+	if err == nil {
+		return nil
+	}
+
+	if info, ok := err.(*ErrorInfo); ok {
+		return info
+	}
+
+	info := &ErrorInfo{
+		Message: err.Error(),
+		Type:    fmt.Sprintf("%T", err),
+		Err:     err,
+	}
+
+	if depth >= maxErrorDepth {
+		return info
+	}
+
+	var causes []error
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		causes = e.Unwrap()
+	case interface{ Unwrap() error }:
+		causes = []error{e.Unwrap()}
+	}
+
+	for _, cause := range causes {
+		if cause != nil {
+			info.Causes = append(info.Causes, newErrorInfo(cause, depth+1))
+		}
+	}
+
+	return info
+}
+
+// Error returns the error's message.
+func (e *ErrorInfo) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the original error.
+func (e *ErrorInfo) Unwrap() error {
+	return e.Err
+}
+
+// errorValue returns the `ErrorInfo` value of "v",
+// if "v" holds an error, otherwise it returns "v" as it is.
+func errorValue(v slog.Value) slog.Value {
+	if v.Kind() != slog.KindAny {
+		return v
+	}
+
+	if err, ok := v.Any().(error); ok {
+		if _, ok = err.(*ErrorInfo); !ok {
+			return slog.AnyValue(NewErrorInfo(err))
+		}
+	}
+
+	return v
+}
+
+// errorArgsField returns the "error" field of the error values
+// found in the log methods' arguments, multiple errors are joined.
+func errorArgsField(errs []error) Field {
+	if len(errs) == 1 {
+		return slog.Any("error", NewErrorInfo(errs[0]))
+	}
+
+	return slog.Any("error", NewErrorInfo(errors.Join(errs...)))
+}
+
+// writeErrorCauses writes an indented cause list
+// for each top-level error field which wraps other errors.
+func writeErrorCauses(w io.Writer, fields FieldList) {
+	for _, f := range fields {
+		if f.Value.Kind() != slog.KindAny {
+			continue
+		}
+
+		if info, ok := f.Value.Any().(*ErrorInfo); ok && len(info.Causes) > 0 {
+			_, _ = fmt.Fprintf(w, "\n  %s: %s: %s", f.Key, info.Type, indentLines(info.Message, "    "))
+			writeCauses(w, info.Causes, 2)
+		}
+	}
+}
+
+func writeCauses(w io.Writer, causes []*ErrorInfo, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, cause := range causes {
+		_, _ = fmt.Fprintf(w, "\n%scaused by: %s: %s", indent, cause.Type, indentLines(cause.Message, indent+"  "))
+		writeCauses(w, cause.Causes, depth+1)
+	}
+}
+
+// indentLines indents all but the first line of "s".
+func indentLines(s, indent string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+indent)
+}
@@ -0,0 +1,340 @@
+package golog
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Field is a key/value pair of a log's structured data.
+// It's an alias of the `slog.Attr`, therefore any slog attribute
+// is a valid Field and its `Value` holds the common kinds without boxing.
+//
+// Nested groups are represented by a Field of `slog.KindGroup` value.
+type Field = slog.Attr
+
+// String returns a Field for a string value.
+func String(key, value string) Field {
+	return slog.String(key, value)
+}
+
+// Int returns a Field for an int value.
+func Int(key string, value int) Field {
+	return slog.Int(key, value)
+}
+
+// Int64 returns a Field for an int64 value.
+func Int64(key string, value int64) Field {
+	return slog.Int64(key, value)
+}
+
+// Uint64 returns a Field for an uint64 value.
+func Uint64(key string, value uint64) Field {
+	return slog.Uint64(key, value)
+}
+
+// Float64 returns a Field for a float64 value.
+func Float64(key string, value float64) Field {
+	return slog.Float64(key, value)
+}
+
+// Bool returns a Field for a bool value.
+func Bool(key string, value bool) Field {
+	return slog.Bool(key, value)
+}
+
+// Duration returns a Field for a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return slog.Duration(key, value)
+}
+
+// Time returns a Field for a time.Time value.
+func Time(key string, value time.Time) Field {
+	return slog.Time(key, value)
+}
+
+// Err returns a Field for an error value, under the "error" key.
+// The error is recorded as an `ErrorInfo`, including its cause tree.
+func Err(err error) Field {
+	return slog.Any("error", NewErrorInfo(err))
+}
+
+// Any returns a Field for any value,
+// `Fields` and maps of string keys are converted to groups.
+func Any(key string, value any) Field {
+	return Field{Key: key, Value: fieldValue(value)}
+}
+
+// Group returns a Field which nests the given "fields" under the "key".
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, Value: slog.GroupValue(fields...)}
+}
+
+// Valuer is a function which returns a field's value lazily,
+// it's called only when the log is going to be printed,
+// after the level check passes. It implements the `slog.LogValuer` interface,
+// values of any other `slog.LogValuer` are resolved lazily as well.
+//
+// Usage:
+//
+//	logger.Debugw("state", "dump", golog.Valuer(func() any { return expensiveDump() }))
+//
+// A Valuer can be passed among the arguments of the log methods too,
+// e.g. `logger.Debug("state: ", golog.Valuer(dump))`.
+type Valuer func() any
+
+// LogValue implements the `slog.LogValuer` interface.
+func (v Valuer) LogValue() slog.Value {
+	return fieldValue(v())
+}
+
+// FieldList is the ordered list of fields of a `Log`.
+// The fields keep the order they were added, through `With`, `WithGroup`,
+// context extractors and the log methods' arguments.
+// The keys of a `Fields` map value are added sorted,
+// as a map has no order.
+//
+// See `Logger.SortFields` for a sorted output.
+type FieldList []Field
+
+// Get returns the value of the top-level field of the given "key".
+// Nested groups are returned as `Fields`.
+func (l FieldList) Get(key string) (any, bool) {
+	for _, f := range l {
+		if f.Key == key {
+			return fieldAny(f.Value), true
+		}
+	}
+
+	return nil, false
+}
+
+// Map returns the fields as a `Fields` map,
+// nested groups are returned as nested `Fields`.
+func (l FieldList) Map() Fields {
+	fields := make(Fields, len(l))
+	for _, f := range l {
+		fields[f.Key] = fieldAny(f.Value)
+	}
+
+	return fields
+}
+
+// Sorted returns a copy of the list sorted by key,
+// the fields of nested groups are sorted too.
+func (l FieldList) Sorted() FieldList {
+	sorted := make(FieldList, len(l))
+	for i, f := range l {
+		if f.Value.Kind() == slog.KindGroup {
+			f.Value = slog.GroupValue(FieldList(f.Value.Group()).Sorted()...)
+		}
+		sorted[i] = f
+	}
+
+	slices.SortStableFunc(sorted, func(a, b Field) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return sorted
+}
+
+// MarshalJSON encodes the fields as a JSON object, in order.
+// Nested groups are encoded as nested objects.
+func (l FieldList) MarshalJSON() ([]byte, error) {
+	return appendJSONFields(nil, l), nil
+}
+
+// fieldAny returns the value of a field, groups are returned as `Fields`.
+func fieldAny(v slog.Value) any {
+	if v.Kind() == slog.KindGroup {
+		return FieldList(v.Group()).Map()
+	}
+
+	return v.Any()
+}
+
+// fieldValue returns the slog value of "v",
+// `Fields` and maps of string keys are converted to groups.
+func fieldValue(v any) slog.Value {
+	switch m := v.(type) {
+	case Fields:
+		return slog.GroupValue(m.fieldList()...)
+	case map[string]any:
+		return slog.GroupValue(Fields(m).fieldList()...)
+	default:
+		return slog.AnyValue(v)
+	}
+}
+
+// fieldList returns the fields of the map sorted by key.
+func (f Fields) fieldList() FieldList {
+	list := make(FieldList, 0, len(f))
+	for _, k := range slices.Sorted(maps.Keys(f)) {
+		list = appendField(list, slog.Attr{Key: k, Value: fieldValue(f[k])})
+	}
+
+	return list
+}
+
+// appendField adds the "attr" to the "list" and returns the new list.
+// A field of an existing key replaces its value in place,
+// groups of the same key are merged and groups with an empty key are inlined.
+// Empty attributes and groups are ignored.
+//
+// The `slog.LogValuer` values are not resolved here, see `resolveFields`.
+func appendField(list FieldList, attr slog.Attr) FieldList {
+	if attr.Equal(slog.Attr{}) {
+		return list
+	}
+
+	attr.Value = errorValue(attr.Value)
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		if attr.Key == "" {
+			for _, groupAttr := range group {
+				list = appendField(list, groupAttr)
+			}
+			return list
+		}
+
+		var fields FieldList
+		for _, groupAttr := range group {
+			fields = appendField(fields, groupAttr)
+		}
+
+		if len(fields) == 0 {
+			return list
+		}
+		attr.Value = slog.GroupValue(fields...)
+	}
+
+	for i, f := range list {
+		if f.Key != attr.Key {
+			continue
+		}
+
+		if f.Value.Kind() == slog.KindGroup && attr.Value.Kind() == slog.KindGroup {
+			merged := mergeFields(FieldList(f.Value.Group()), FieldList(attr.Value.Group()))
+			attr.Value = slog.GroupValue(merged...)
+		}
+
+		list[i] = attr
+		return list
+	}
+
+	return append(list, attr)
+}
+
+// needsResolve reports whether any of the "fields",
+// including the nested ones, holds a `slog.LogValuer` value.
+func needsResolve(fields FieldList) bool {
+	for _, f := range fields {
+		switch f.Value.Kind() {
+		case slog.KindLogValuer:
+			return true
+		case slog.KindGroup:
+			if needsResolve(f.Value.Group()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolveFields resolves the `slog.LogValuer` values of the "fields",
+// it's called only when a log is going to be printed.
+// The "fields" are never modified, a new list is returned if any value was resolved.
+func resolveFields(fields FieldList) FieldList {
+	if !needsResolve(fields) {
+		return fields
+	}
+
+	resolved := make(FieldList, 0, len(fields))
+	for _, f := range fields {
+		f.Value = f.Value.Resolve()
+		if f.Value.Kind() == slog.KindGroup {
+			f.Value = slog.GroupValue(resolveFields(f.Value.Group())...)
+		}
+		resolved = appendField(resolved, f)
+	}
+
+	return resolved
+}
+
+// argsToFields converts slog-style arguments to fields.
+// Accepts `Fields`, `Field` (`slog.Attr`), `[]slog.Attr` and
+// alternating string keys and values, i.e "service", "api".
+// A value without a key is stored under the "!BADKEY" key, like slog does.
+func argsToFields(args []any) FieldList {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fields := make(FieldList, 0, len(args)/2+1)
+	for i := 0; i < len(args); i++ {
+		switch v := args[i].(type) {
+		case Fields:
+			for _, f := range v.fieldList() {
+				fields = appendField(fields, f)
+			}
+		case FieldList:
+			for _, f := range v {
+				fields = appendField(fields, f)
+			}
+		case []slog.Attr:
+			for _, attr := range v {
+				fields = appendField(fields, attr)
+			}
+		case slog.Attr:
+			fields = appendField(fields, v)
+		case string:
+			if i+1 < len(args) {
+				fields = appendField(fields, slog.Attr{Key: v, Value: fieldValue(args[i+1])})
+				i++
+			} else {
+				fields = appendField(fields, slog.String("!BADKEY", v))
+			}
+		default:
+			fields = appendField(fields, slog.Any("!BADKEY", v))
+		}
+	}
+
+	return fields
+}
+
+// mergeFields returns the "base" fields followed by the "extra" ones.
+// Fields of an existing key are overridden in place,
+// nested fields (groups) of the same key are merged too.
+// The "base" is never modified.
+func mergeFields(base, extra FieldList) FieldList {
+	if len(extra) == 0 {
+		return base
+	}
+
+	if len(base) == 0 {
+		return extra
+	}
+
+	fields := make(FieldList, len(base), len(base)+len(extra))
+	copy(fields, base)
+	for _, f := range extra {
+		fields = appendField(fields, f)
+	}
+
+	return fields
+}
+
+// groupFields nests the "fields" under the given "groups", in order.
+func groupFields(groups []string, fields FieldList) FieldList {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		fields = FieldList{slog.Attr{Key: groups[i], Value: slog.GroupValue(fields...)}}
+	}
+
+	return fields
+}
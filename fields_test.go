@@ -0,0 +1,43 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+)
+
+type requestIDKey struct{}
+
+func TestFieldsOrder(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New().SetOutput(&buf).SetTimeFormat("")
+	logger.AddContextExtractor(func(ctx context.Context) Fields {
+		return Fields{"request_id": ctx.Value(requestIDKey{})}
+	})
+
+	child := logger.With("zone", "eu", "service", "api").
+		WithGroup("http").With("method", "GET").
+		Child("router")
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+	child.InfoContext(ctx, "handled", Int("status", 200), String("path", "/"))
+
+	expected := "[INFO] router: handled zone=eu service=api http.method=GET http.status=200 http.path=/ request_id=abc\n"
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected %q but got %q", expected, got)
+	}
+
+	var got []string
+	child.Handle(func(log *Log) bool {
+		for _, field := range log.Fields {
+			got = append(got, field.Key)
+		}
+		return true
+	})
+	child.InfoContext(ctx, "handled", Int("status", 200))
+
+	if expected := []string{"zone", "service", "http", "request_id"}; !slices.Equal(got, expected) {
+		t.Fatalf("expected the fields %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,424 @@
+package golog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileOptions holds the rotation options of the `FileOutput`.
+type FileOptions struct {
+	// MaxSize is the maximum size, in bytes, of a log file before it gets rotated.
+	// Zero means no size limit.
+	MaxSize int64
+	// Interval rotates the log file on every multiple of the interval,
+	// e.g. 24*time.Hour rotates it daily, at midnight UTC.
+	// Zero means no time-based rotation.
+	Interval time.Duration
+	// MaxBackups is the maximum number of rotated files to keep,
+	// the oldest ones are removed. Zero keeps all of them.
+	MaxBackups int
+	// MaxAge is the maximum age of the rotated files to keep, based on the time
+	// in their names, i.e. of their rotation, or creation with Symlink, in local time.
+	// Zero keeps all of them.
+	MaxAge time.Duration
+	// Compress compresses the rotated files with gzip, in the background.
+	Compress bool
+	// Symlink writes the logs to files named after their creation time instead,
+	// e.g. "app-2006-01-02T15-04-05.000.log", and keeps a symbolic link
+	// at the output's path which points to the current one.
+	Symlink bool
+	// FileMode is the permissions of the log files, defaults to 0644.
+	FileMode os.FileMode
+}
+
+// FileBackupTimeFormat is the layout of the time in the names of the rotated log files.
+const FileBackupTimeFormat = "2006-01-02T15-04-05.000"
+
+// FileOutput is an `io.WriteCloser` which writes the logs to a file
+// and rotates it by size and/or time. The rotated files are named after
+// the path and the time of their rotation (or creation, see `FileOptions.Symlink`),
+// e.g. "app-2006-01-02T15-04-05.000.log", in the same directory.
+//
+// Usage:
+//
+//	output, err := golog.NewFileOutput("./logs/app.log", golog.FileOptions{
+//		MaxSize:    100 << 20,
+//		MaxBackups: 7,
+//		Compress:   true,
+//	})
+//	logger.SetOutput(output)
+//	defer output.Close()
+type FileOutput struct {
+	path string
+	opts FileOptions
+
+	mu       sync.Mutex
+	file     *os.File
+	name     string // the current file's name.
+	size     int64
+	rotateAt time.Time // the next time-based rotation.
+	closed   bool
+
+	millMu sync.Mutex // serializes the compression and removal of the rotated files.
+	wg     sync.WaitGroup
+}
+
+// NewFileOutput returns a new file output which writes the logs to the "path" file,
+// the directories of the path are created if they don't exist.
+// It appends to an existing log file and removes the rotated files which exceed the limits.
+func NewFileOutput(path string, opts FileOptions) (*FileOutput, error) {
+	if opts.FileMode == 0 {
+		opts.FileMode = 0o644
+	}
+
+	// the rotated files' names are compared to the ones listed in its directory.
+	path = filepath.Clean(path)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	if opts.Symlink {
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink == 0 {
+			return nil, fmt.Errorf("golog: file output: %s exists and it's not a symbolic link", path)
+		}
+	}
+
+	w := &FileOutput{path: path, opts: opts}
+
+	now, name := time.Now(), path
+	if opts.Symlink {
+		if name = w.currentSymlinkTarget(); name == "" {
+			name = w.backupName(now)
+		}
+	}
+
+	if err := w.open(name, now); err != nil {
+		return nil, err
+	}
+
+	// remove the rotated files of previous runs which exceed the limits.
+	if opts.MaxBackups > 0 || opts.MaxAge > 0 {
+		w.wg.Add(1)
+		go w.mill("")
+	}
+
+	return w, nil
+}
+
+// open opens the "name" log file, it continues an existing one,
+// and makes it the current one.
+func (w *FileOutput) open(name string, now time.Time) error {
+	file, size, err := w.openFile(name)
+	if err != nil {
+		return err
+	}
+
+	w.use(file, name, size, now)
+	return nil
+}
+
+// openFile opens the "name" log file, it continues an existing one,
+// and points the symbolic link to it, if enabled.
+func (w *FileOutput) openFile(name string) (*os.File, int64, error) {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, w.opts.FileMode)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	if w.opts.Symlink {
+		if err = w.link(name); err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+	}
+
+	return file, info.Size(), nil
+}
+
+// use makes the "file" the current log file and schedules its time-based rotation.
+func (w *FileOutput) use(file *os.File, name string, size int64, now time.Time) {
+	w.file, w.name, w.size = file, name, size
+	if w.opts.Interval > 0 {
+		w.rotateAt = now.Truncate(w.opts.Interval).Add(w.opts.Interval)
+	}
+}
+
+// currentSymlinkTarget returns the existing file of the symbolic link, if any.
+func (w *FileOutput) currentSymlinkTarget() string {
+	target, err := os.Readlink(w.path)
+	if err != nil {
+		return ""
+	}
+
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(w.path), target)
+	}
+
+	if _, err = os.Stat(target); err != nil {
+		return ""
+	}
+
+	return target
+}
+
+// link points the symbolic link of the path to the "name" file, atomically.
+func (w *FileOutput) link(name string) error {
+	tmp := w.path + ".tmp"
+	os.Remove(tmp)
+
+	if err := os.Symlink(filepath.Base(name), tmp); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, w.path)
+}
+
+// backupName returns the name of a log file of the given time.
+// A counter is added to the time when a file of the same time exists,
+// compressed or not, e.g. "app-2006-01-02T15-04-05.000-1.log".
+func (w *FileOutput) backupName(t time.Time) string {
+	ext := filepath.Ext(w.path)
+	base := strings.TrimSuffix(w.path, ext) + "-" + t.Format(FileBackupTimeFormat)
+
+	name := base + ext
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = base + "-" + strconv.Itoa(i) + ext
+	}
+
+	return name
+}
+
+func fileExists(name string) bool {
+	_, err := os.Lstat(name)
+	return err == nil
+}
+
+// Write writes "p" to the current log file, it rotates the file first
+// if "p" exceeds its maximum size or its rotation time has come.
+func (w *FileOutput) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+	now := time.Now()
+	if (w.opts.MaxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.opts.MaxSize) ||
+		(!w.rotateAt.IsZero() && !now.Before(w.rotateAt)) {
+		// on failure, "p" is still written to the current file.
+		rotateErr = w.rotate(now)
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+
+	return n, err
+}
+
+// Size returns the size of the current log file.
+func (w *FileOutput) Size() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.size
+}
+
+// Rotate closes the current log file, keeps it as a rotated file and opens a new one.
+func (w *FileOutput) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return os.ErrClosed
+	}
+
+	return w.rotate(time.Now())
+}
+
+// rotate opens the new log file and then closes the current one,
+// on failure the current one is kept.
+func (w *FileOutput) rotate(now time.Time) error {
+	rotated, name := w.name, w.backupName(now)
+	if !w.opts.Symlink {
+		if err := os.Rename(w.path, name); err != nil {
+			return err
+		}
+		rotated, name = name, w.path
+	}
+
+	file, size, err := w.openFile(name)
+	if err != nil {
+		if !w.opts.Symlink {
+			_ = os.Rename(rotated, w.path) // restore the current one's name.
+		}
+		return err
+	}
+
+	previous := w.file
+	w.use(file, name, size, now)
+	_ = previous.Close()
+
+	w.wg.Add(1)
+	go w.mill(rotated)
+	return nil
+}
+
+// mill compresses the "rotated" file, if any, and removes the rotated files
+// which exceed the maximum number of backups or age.
+func (w *FileOutput) mill(rotated string) {
+	defer w.wg.Done()
+
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	if w.opts.Compress && rotated != "" {
+		_ = compressFile(rotated, w.opts.FileMode)
+	}
+
+	if w.opts.MaxBackups <= 0 && w.opts.MaxAge <= 0 {
+		return
+	}
+
+	w.mu.Lock()
+	current := w.name
+	w.mu.Unlock()
+
+	backups := w.backups(current)
+	cutoff := time.Now().Add(-w.opts.MaxAge)
+	for i, backup := range backups {
+		if (w.opts.MaxBackups > 0 && i >= w.opts.MaxBackups) ||
+			(w.opts.MaxAge > 0 && backup.time.Before(cutoff)) {
+			_ = os.Remove(backup.name)
+		}
+	}
+}
+
+type fileBackup struct {
+	name string
+	time time.Time // the time in its name.
+	seq  int       // the counter in its name, if any.
+}
+
+// backups returns the rotated files, newest first, except the "current" one.
+func (w *FileOutput) backups(current string) []fileBackup {
+	dir := filepath.Dir(w.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), ext) + "-"
+
+	var backups []fileBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ext)[len(prefix):]
+		if len(stamp) < len(FileBackupTimeFormat) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(FileBackupTimeFormat, stamp[:len(FileBackupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+
+		seq := 0
+		if counter := stamp[len(FileBackupTimeFormat):]; counter != "" {
+			if seq, err = strconv.Atoi(strings.TrimPrefix(counter, "-")); err != nil || counter[0] != '-' {
+				continue
+			}
+		}
+
+		name = filepath.Join(dir, name)
+		if name == current {
+			continue
+		}
+
+		backups = append(backups, fileBackup{name: name, time: t, seq: seq})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].time.Equal(backups[j].time) {
+			return backups[i].time.After(backups[j].time)
+		}
+		return backups[i].seq > backups[j].seq
+	})
+
+	return backups
+}
+
+// compressFile compresses the "name" file to "name.gz" and removes it.
+func compressFile(name string, mode os.FileMode) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := name + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err = os.Rename(tmp, name+".gz"); err != nil {
+		return err
+	}
+
+	return os.Remove(name)
+}
+
+// Close closes the current log file and waits for the
+// compression and removal of the rotated files to finish.
+func (w *FileOutput) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	err := w.file.Close()
+	w.mu.Unlock()
+
+	w.wg.Wait()
+	return err
+}
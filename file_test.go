@@ -0,0 +1,230 @@
+package golog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readFile reads the "name" log file, it decompresses the gzip ones.
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func writeFile(t *testing.T, w io.Writer, data string) {
+	t.Helper()
+
+	if _, err := w.Write([]byte(data)); err != nil {
+		t.Fatalf("write %q: %v", data, err)
+	}
+}
+
+// fileBackupNames returns the names of the rotated files of the output, newest first.
+func fileBackupNames(w *FileOutput) []string {
+	w.wg.Wait() // the compression and removal of the rotated files.
+
+	var names []string
+	for _, backup := range w.backups(w.name) {
+		names = append(names, backup.name)
+	}
+
+	return names
+}
+
+func TestFileOutputMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	output, err := NewFileOutput(path, FileOptions{MaxSize: 10, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	writeFile(t, output, "12345\n")
+	writeFile(t, output, "67890\n") // exceeds the maximum size.
+
+	if got := readFile(t, path); got != "67890\n" {
+		t.Fatalf("expected the current file to hold the last write but got %q", got)
+	}
+
+	if size := output.Size(); size != 6 {
+		t.Fatalf("expected a size of 6 but got %d", size)
+	}
+
+	backups := fileBackupNames(output)
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".log.gz") {
+		t.Fatalf("expected 1 compressed backup but got %q", backups)
+	}
+
+	if got := readFile(t, backups[0]); got != "12345\n" {
+		t.Fatalf("expected the backup to hold the first write but got %q", got)
+	}
+}
+
+func TestFileOutputInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	output, err := NewFileOutput(path, FileOptions{Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	writeFile(t, output, "before\n")
+
+	output.mu.Lock()
+	output.rotateAt = time.Now().Add(-time.Second) // the rotation time has come.
+	output.mu.Unlock()
+
+	writeFile(t, output, "after\n")
+
+	if got := readFile(t, path); got != "after\n" {
+		t.Fatalf("expected the current file to hold the last write but got %q", got)
+	}
+
+	if !output.rotateAt.After(time.Now()) {
+		t.Fatalf("expected the next rotation to be scheduled but got %s", output.rotateAt)
+	}
+
+	backups := fileBackupNames(output)
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup but got %q", backups)
+	}
+
+	if got := readFile(t, backups[0]); got != "before\n" {
+		t.Fatalf("expected the backup to hold the first write but got %q", got)
+	}
+}
+
+func TestFileOutputMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	output, err := NewFileOutput(path, FileOptions{MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	for _, data := range []string{"1\n", "2\n", "3\n"} {
+		writeFile(t, output, data)
+		if err = output.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups := fileBackupNames(output)
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups but got %q", backups)
+	}
+
+	// newest first.
+	for i, expected := range []string{"3\n", "2\n"} {
+		if got := readFile(t, backups[i]); got != expected {
+			t.Fatalf("expected the backup %d to hold %q but got %q", i, expected, got)
+		}
+	}
+}
+
+func TestFileOutputMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "app-"+time.Now().Add(-48*time.Hour).Format(FileBackupTimeFormat)+".log")
+	recent := filepath.Join(dir, "app-"+time.Now().Add(-time.Minute).Format(FileBackupTimeFormat)+".log")
+	for _, name := range []string{old, recent} {
+		if err := os.WriteFile(name, []byte("backup\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := NewFileOutput(filepath.Join(dir, "app.log"), FileOptions{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	if backups := fileBackupNames(output); len(backups) != 1 || backups[0] != recent {
+		t.Fatalf("expected the backups of the last hour only but got %q", backups)
+	}
+}
+
+func TestFileOutputSymlink(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// the current file of a previous run, older than MaxAge.
+	if err := os.Mkdir("logs", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	previous := "app-" + time.Now().Add(-48*time.Hour).Format(FileBackupTimeFormat) + ".log"
+	if err := os.WriteFile(filepath.Join("logs", previous), []byte("previous\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(previous, filepath.Join("logs", "app.log")); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := NewFileOutput("./logs/app.log", FileOptions{MaxBackups: 1, MaxAge: time.Hour, Symlink: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	writeFile(t, output, "continued\n")
+	if backups := fileBackupNames(output); len(backups) != 0 {
+		t.Fatalf("expected the current file not to be a backup but got %q", backups)
+	}
+
+	if got := readFile(t, "./logs/app.log"); got != "previous\ncontinued\n" {
+		t.Fatalf("expected the previous run's file to be continued but got %q", got)
+	}
+
+	for _, data := range []string{"1\n", "2\n"} {
+		if err = output.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, output, data)
+	}
+
+	backups := fileBackupNames(output)
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup but got %q", backups)
+	}
+
+	if got := readFile(t, backups[0]); got != "1\n" {
+		t.Fatalf("expected the backup to hold %q but got %q", "1\n", got)
+	}
+
+	if got := readFile(t, "./logs/app.log"); got != "2\n" {
+		t.Fatalf("expected the symbolic link to point to the current file but got %q", got)
+	}
+
+	target, err := os.Readlink(filepath.Join("logs", "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Join("logs", target) != output.name {
+		t.Fatalf("expected the symbolic link to point to %s but got %s", output.name, target)
+	}
+}
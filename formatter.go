@@ -1,8 +1,10 @@
 package golog
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
+	"strconv"
 	"sync"
 )
 
@@ -17,17 +19,106 @@ type Formatter interface {
 	Format(dest io.Writer, log *Log) bool
 }
 
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// acquireBuffer returns an empty byte buffer from the pool.
+func acquireBuffer() *[]byte {
+	buf := bufferPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// releaseBuffer releases the buffer back to the pool, large buffers are dropped.
+func releaseBuffer(buf *[]byte) {
+	if cap(*buf) > 64<<10 {
+		return
+	}
+
+	bufferPool.Put(buf)
+}
+
+// The time encodings of the `JSONOptions.TimeFormat`,
+// any other value is a time layout, e.g. `time.RFC3339Nano`.
+const (
+	// JSONTimeUnix encodes the time as Unix seconds, the default one.
+	JSONTimeUnix = "unix"
+	// JSONTimeUnixMilli encodes the time as Unix milliseconds.
+	JSONTimeUnixMilli = "unixmilli"
+	// JSONTimeUnixNano encodes the time as Unix nanoseconds.
+	JSONTimeUnixNano = "unixnano"
+)
+
+// JSONOptions holds the options of the JSON Formatter's default layout,
+// the profiles have their own key names.
+//
+// Usage:
+//
+//	logger.SetFormat("json", golog.JSONOptions{
+//		TimeKey:       "ts",
+//		TimeFormat:    time.RFC3339Nano,
+//		FlattenFields: true,
+//	})
+type JSONOptions struct {
+	// TimeKey is the key of the time, defaults to "timestamp".
+	TimeKey string
+	// LevelKey is the key of the level, defaults to "level".
+	LevelKey string
+	// MessageKey is the key of the message, defaults to "message".
+	MessageKey string
+	// FieldsKey is the key of the fields object, defaults to "fields".
+	FieldsKey string
+	// TimeFormat is the encoding of the time: `JSONTimeUnix` (the default),
+	// `JSONTimeUnixMilli`, `JSONTimeUnixNano` or a time layout, e.g. `time.RFC3339Nano`.
+	// The time is omitted when the Logger's `TimeFormat` is empty.
+	TimeFormat string
+	// LevelNumber writes the level as its `SeverityNumber` instead of its name.
+	LevelNumber bool
+	// FlattenFields writes the fields at the top level.
+	// Fields whose keys are used by the log itself are kept under the FieldsKey.
+	FlattenFields bool
+	// OmitStacktrace omits the stacktrace of the logs.
+	OmitStacktrace bool
+}
+
+// withDefaults returns a copy of the options with the empty keys set to their defaults.
+func (o JSONOptions) withDefaults() JSONOptions {
+	if o.TimeKey == "" {
+		o.TimeKey = "timestamp"
+	}
+	if o.LevelKey == "" {
+		o.LevelKey = "level"
+	}
+	if o.MessageKey == "" {
+		o.MessageKey = "message"
+	}
+	if o.FieldsKey == "" {
+		o.FieldsKey = "fields"
+	}
+	if o.TimeFormat == "" {
+		o.TimeFormat = JSONTimeUnix
+	}
+
+	return o
+}
+
 // JSONFormatter is a Formatter type for JSON logs.
 type JSONFormatter struct {
 	Indent string
-
-	// Use one encoder per level, do not create new each time.
-	// Even if the jser can set a different formatter for each level
-	// on SetLevelFormat, the encoding's writers may be different
-	// if that ^ is not called but SetLevelOutput did provide a different writer.
-	encoders map[Level]*json.Encoder
-	mu       sync.RWMutex // encoders locker.
-	encMu    sync.Mutex   // encode action locker.
+	// Profile is the field layout of the logs, see `JSONProfile`.
+	// The logs of a profile other than the default are written on a single line,
+	// the Indent is ignored.
+	Profile JSONProfile
+	// ProjectID is the Google Cloud project of the "logging.googleapis.com/trace" field,
+	// used by the `JSONProfileGCP`. Defaults to the "GOOGLE_CLOUD_PROJECT" environment variable.
+	ProjectID string
+	// Config holds the key names, the time and level encoding
+	// and the fields layout of the default profile.
+	Config JSONOptions
 }
 
 // String returns the name of the Formatter.
@@ -37,21 +128,30 @@ func (f *JSONFormatter) String() string {
 	return "json"
 }
 
-// Options sets the options for the JSON Formatter (currently only indent).
+// Options sets the options for the JSON Formatter,
+// a string sets the indent, a `JSONProfile` the field layout
+// and a `JSONOptions` the key names and encodings,
+// e.g. logger.SetFormat("json", golog.JSONProfileGCP).
 func (f *JSONFormatter) Options(opts ...any) Formatter {
 	formatter := &JSONFormatter{
-		Indent:   "  ",
-		encoders: make(map[Level]*json.Encoder, len(Levels)),
+		Indent:    "  ",
+		Profile:   f.Profile,
+		ProjectID: f.ProjectID,
+		Config:    f.Config,
 	}
 
 	for _, opt := range opts {
-		if opt == nil {
-			continue
-		}
-
-		if indent, ok := opt.(string); ok {
-			formatter.Indent = indent
-			break
+		switch v := opt.(type) {
+		case string:
+			formatter.Indent = v
+		case JSONProfile:
+			formatter.Profile = v
+		case JSONOptions:
+			formatter.Config = v
+		case *JSONOptions:
+			if v != nil {
+				formatter.Config = *v
+			}
 		}
 	}
 
@@ -64,20 +164,135 @@ func (f *JSONFormatter) Options(opts ...any) Formatter {
 // logger.SetFormat("json") or
 // logger.SetLevelFormat("info", "json")
 func (f *JSONFormatter) Format(dest io.Writer, log *Log) bool {
-	f.mu.RLock()
-	enc, ok := f.encoders[log.Level]
-	f.mu.RUnlock()
+	if f.Profile != JSONProfileDefault {
+		return f.formatProfile(dest, log)
+	}
+
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	buf := appendJSONRecord(*bufPtr, log, f.Config.withDefaults())
+	buf = append(buf, '\n')
+	*bufPtr = buf
+
+	if f.Indent != "" {
+		indentPtr := acquireBuffer()
+		defer releaseBuffer(indentPtr)
 
-	if !ok {
-		enc = json.NewEncoder(dest)
-		enc.SetIndent("", f.Indent)
-		f.mu.Lock()
-		f.encoders[log.Level] = enc
-		f.mu.Unlock()
+		indented := bytes.NewBuffer(*indentPtr)
+		if json.Indent(indented, buf, "", f.Indent) == nil {
+			buf = indented.Bytes()
+			*indentPtr = buf
+		}
 	}
 
-	f.encMu.Lock()
-	err := enc.Encode(log)
-	f.encMu.Unlock()
+	_, err := dest.Write(buf)
 	return err == nil
 }
+
+// appendJSONRecord appends the "log" as a JSON object, in the layout of the "config".
+func appendJSONRecord(buf []byte, log *Log, config JSONOptions) []byte {
+	buf = append(buf, '{')
+	start := len(buf)
+	buf = appendJSONTime(buf, log, config)
+
+	if len(buf) > start {
+		buf = append(buf, ',')
+	}
+	buf = appendJSONString(buf, config.LevelKey)
+	buf = append(buf, ':')
+	if config.LevelNumber {
+		var n int
+		if meta, ok := Levels[log.Level]; ok {
+			n = meta.SeverityNumber
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	} else {
+		buf = appendJSONString(buf, log.Level.String())
+	}
+
+	buf = append(buf, ',')
+	buf = appendJSONString(buf, config.MessageKey)
+	buf = append(buf, ':')
+	buf = appendJSONString(buf, log.Message)
+
+	fields := log.Fields
+	if config.FlattenFields {
+		var reserved FieldList
+		for _, field := range log.Fields {
+			switch field.Key {
+			case config.TimeKey, config.LevelKey, config.MessageKey, config.FieldsKey, "caller", "stacktrace":
+				reserved = append(reserved, field)
+				continue
+			}
+
+			buf = append(buf, ',')
+			buf = appendJSONString(buf, field.Key)
+			buf = append(buf, ':')
+			buf = appendJSONValue(buf, field.Value)
+		}
+		fields = reserved
+	}
+
+	if len(fields) > 0 {
+		buf = append(buf, ',')
+		buf = appendJSONString(buf, config.FieldsKey)
+		buf = append(buf, ':')
+		buf = appendJSONFields(buf, fields)
+	}
+
+	if !log.Caller.IsZero() {
+		buf = append(buf, `,"caller":`...)
+		buf = appendJSONFrame(buf, log.Caller)
+	}
+
+	if len(log.Stacktrace) > 0 && !config.OmitStacktrace {
+		buf = append(buf, `,"stacktrace":[`...)
+		for i, frame := range log.Stacktrace {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONFrame(buf, frame)
+		}
+		buf = append(buf, ']')
+	}
+
+	return append(buf, '}')
+}
+
+// appendJSONFrame appends the frame as an object of its "function" and "source".
+func appendJSONFrame(buf []byte, frame Frame) []byte {
+	buf = append(buf, `{"function":`...)
+	buf = appendJSONString(buf, frame.Function)
+	buf = append(buf, `,"source":`...)
+	buf = appendJSONString(buf, frame.Source)
+	return append(buf, '}')
+}
+
+// appendJSONTime appends the time's key and value. Like the text output,
+// the time is omitted when the Logger's TimeFormat is empty.
+func appendJSONTime(buf []byte, log *Log, config JSONOptions) []byte {
+	if (log.Time.IsZero() && log.Timestamp == 0) || (log.Logger != nil && log.Logger.TimeFormat == "") {
+		return buf
+	}
+
+	buf = appendJSONString(buf, config.TimeKey)
+	buf = append(buf, ':')
+
+	switch config.TimeFormat {
+	case JSONTimeUnix:
+		timestamp := log.Timestamp
+		if timestamp == 0 {
+			timestamp = log.Time.Unix()
+		}
+		return strconv.AppendInt(buf, timestamp, 10)
+	case JSONTimeUnixMilli:
+		return strconv.AppendInt(buf, log.Time.UnixMilli(), 10)
+	case JSONTimeUnixNano:
+		return strconv.AppendInt(buf, log.Time.UnixNano(), 10)
+	default:
+		buf = append(buf, '"')
+		buf = log.Time.AppendFormat(buf, config.TimeFormat)
+		return append(buf, '"')
+	}
+}
@@ -0,0 +1,402 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// GELFFormatter is a Formatter type for Graylog Extended Log Format (GELF) 1.1 logs, e.g.
+//
+//	{"version":"1.1","host":"api-1","short_message":"query failed","timestamp":1756059304.123,"level":3,"_logger":"db","_table":"users"}
+//
+// The `full_message` is written when the message has more lines, an error with causes
+// or a stacktrace. The `level` is the syslog severity of the log's level,
+// based on its `LevelMetadata.SeverityNumber`. The fields are written as additional fields,
+// prefixed by an underscore, with their nested keys joined by an underscore too.
+//
+// Pair it with a `GELFOutput` to send the logs to Graylog.
+type GELFFormatter struct {
+	// Host is the name of the host which sends the logs,
+	// defaults to the `os.Hostname`.
+	Host string
+}
+
+var hostname = sync.OnceValue(func() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return "localhost"
+	}
+
+	return host
+})
+
+// String returns the name of the Formatter.
+// In this case it returns "gelf".
+// It's used to map the formatter names with their implementations.
+func (f *GELFFormatter) String() string {
+	return "gelf"
+}
+
+// Options sets the options for the GELF Formatter and returns a new one.
+// A string option sets the `Host`.
+func (f *GELFFormatter) Options(opts ...any) Formatter {
+	formatter := &GELFFormatter{Host: f.Host}
+	for _, opt := range opts {
+		if host, ok := opt.(string); ok {
+			formatter.Host = host
+		}
+	}
+
+	return formatter
+}
+
+// Format prints the logs in GELF 1.1 format.
+//
+// Usage:
+// logger.SetFormat("gelf") or
+// logger.SetLevelFormat("error", "gelf")
+func (f *GELFFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	host := f.Host
+	if host == "" {
+		host = hostname()
+	}
+
+	shortMessage, _, multiline := strings.Cut(log.Message, "\n")
+	if shortMessage == "" {
+		shortMessage = "-" // short_message is required and can't be empty.
+	}
+
+	buf := append(*bufPtr, `{"version":"1.1","host":`...)
+	buf = appendJSON(buf, host)
+	buf = append(buf, `,"short_message":`...)
+	buf = appendJSON(buf, shortMessage)
+
+	var causes strings.Builder
+	writeErrorCauses(&causes, log.Fields)
+	if multiline || causes.Len() > 0 || len(log.Stacktrace) > 0 {
+		fullMessage := log.Message + causes.String()
+		if len(log.Stacktrace) > 0 {
+			fullMessage += "\n\n" + stacktraceString(log.Stacktrace)
+		}
+
+		buf = append(buf, `,"full_message":`...)
+		buf = appendJSON(buf, fullMessage)
+	}
+
+	buf = append(buf, `,"timestamp":`...)
+	buf = strconv.AppendFloat(buf, float64(log.Time.UnixMicro())/1e6, 'f', -1, 64)
+	buf = append(buf, `,"level":`...)
+	buf = strconv.AppendInt(buf, int64(log.Level.syslogSeverity()), 10)
+
+	if prefix := log.Prefix(); prefix != "" {
+		buf = appendGELFField(buf, "logger", slog.StringValue(prefix))
+	}
+
+	if !log.Caller.IsZero() {
+		buf = appendGELFField(buf, "file", slog.StringValue(log.Caller.File))
+		buf = appendGELFField(buf, "line", slog.IntValue(log.Caller.Line))
+		buf = appendGELFField(buf, "function", slog.StringValue(log.Caller.Function))
+	}
+
+	buf = appendGELFFields(buf, "", log.Fields)
+	buf = append(buf, "}\n"...)
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+func appendGELFFields(buf []byte, prefix string, fields FieldList) []byte {
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			buf = appendGELFFields(buf, prefix+field.Key+"_", field.Value.Group())
+			continue
+		}
+
+		buf = appendGELFField(buf, prefix+field.Key, field.Value)
+	}
+
+	return buf
+}
+
+// appendGELFField appends an additional field,
+// its value is written as a number or a string, as GELF requires.
+func appendGELFField(buf []byte, key string, value slog.Value) []byte {
+	key = gelfKey(key)
+	if key == "_id" { // reserved by GELF.
+		key = "_id_"
+	}
+
+	buf = append(buf, ',')
+	buf = appendJSON(buf, key)
+	buf = append(buf, ':')
+
+	switch value.Kind() {
+	case slog.KindInt64:
+		return strconv.AppendInt(buf, value.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.AppendUint(buf, value.Uint64(), 10)
+	case slog.KindFloat64:
+		return appendJSON(buf, value.Float64())
+	default:
+		return appendJSON(buf, value.String())
+	}
+}
+
+// gelfKey returns the "_"-prefixed key of an additional field,
+// the characters which are not allowed by GELF are replaced with an underscore.
+func gelfKey(key string) string {
+	return "_" + strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// GELFCompression is the compression of the GELF messages sent over UDP.
+type GELFCompression int
+
+const (
+	// GELFNoCompression sends the messages uncompressed.
+	GELFNoCompression GELFCompression = iota
+	// GELFGzip compresses the messages with gzip.
+	GELFGzip
+	// GELFZlib compresses the messages with zlib.
+	GELFZlib
+)
+
+const (
+	// DefaultGELFChunkSize is the default maximum size of a GELF UDP chunk, the header included,
+	// it's small enough to be sent through the internet without fragmentation.
+	DefaultGELFChunkSize = 1420
+
+	gelfChunkHeaderSize = 12
+	gelfMaxChunks       = 128
+)
+
+// ErrGELFMessageTooLarge is returned by a UDP `GELFOutput`
+// when a message needs more than 128 chunks.
+var ErrGELFMessageTooLarge = errors.New("golog: gelf: message too large")
+
+// GELFOptions holds the options of a `GELFOutput`.
+type GELFOptions struct {
+	// Compression is the compression of the messages sent over UDP,
+	// the TCP messages are never compressed as Graylog does not support it.
+	// Defaults to GELFNoCompression.
+	Compression GELFCompression
+	// ChunkSize is the maximum size of a UDP chunk, the chunk header included.
+	// Defaults to DefaultGELFChunkSize.
+	ChunkSize int
+}
+
+// GELFOutput is an `io.Writer` which sends GELF messages to a Graylog input,
+// over UDP, with chunking and optional compression, or over TCP,
+// framed by a null byte. Each Write sends one message,
+// the trailing new line written by the `GELFFormatter` is removed.
+//
+// Usage:
+//
+//	output, err := golog.NewGELFOutput("udp", "graylog:12201", golog.GELFOptions{Compression: golog.GELFGzip})
+//	logger.SetOutput(output).SetFormat("gelf")
+type GELFOutput struct {
+	network string
+	address string
+	opts    GELFOptions
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+	buf    bytes.Buffer // compression buffer.
+	gzip   *gzip.Writer
+	zlib   *zlib.Writer
+}
+
+// NewGELFOutput returns a new GELF output which sends the messages to the "address"
+// of the given "network", "udp" or "tcp".
+func NewGELFOutput(network, address string, opts GELFOptions) (*GELFOutput, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("golog: gelf: unsupported network %q", network)
+	}
+
+	if opts.ChunkSize <= gelfChunkHeaderSize {
+		opts.ChunkSize = DefaultGELFChunkSize
+	}
+
+	w := &GELFOutput{
+		network: network,
+		address: address,
+		opts:    opts,
+	}
+
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	w.conn = conn
+
+	return w, nil
+}
+
+func (w *GELFOutput) isUDP() bool {
+	return strings.HasPrefix(w.network, "udp")
+}
+
+// Write sends "p" as one GELF message.
+func (w *GELFOutput) Write(p []byte) (int, error) {
+	message := bytes.TrimRight(p, "\n")
+	if len(message) == 0 {
+		return len(p), nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, net.ErrClosed
+	}
+
+	var err error
+	if w.isUDP() {
+		err = w.writeUDP(message)
+	} else {
+		err = w.writeTCP(message)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (w *GELFOutput) writeUDP(message []byte) error {
+	message, err := w.compress(message)
+	if err != nil {
+		return err
+	}
+
+	if len(message) <= w.opts.ChunkSize {
+		_, err = w.conn.Write(message)
+		return err
+	}
+
+	dataSize := w.opts.ChunkSize - gelfChunkHeaderSize
+	count := (len(message) + dataSize - 1) / dataSize
+	if count > gelfMaxChunks {
+		return ErrGELFMessageTooLarge
+	}
+
+	chunk := make([]byte, 0, w.opts.ChunkSize)
+	chunk = append(chunk, 0x1e, 0x0f)
+	chunk = append(chunk, make([]byte, 8)...)
+	_, _ = rand.Read(chunk[2:10]) // the message id.
+	chunk = append(chunk, 0, byte(count))
+
+	for i := range count {
+		end := min((i+1)*dataSize, len(message))
+		chunk = append(chunk[:gelfChunkHeaderSize], message[i*dataSize:end]...)
+		chunk[10] = byte(i)
+
+		if _, err = w.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compress returns the compressed "message", the result is valid until the next call.
+func (w *GELFOutput) compress(message []byte) ([]byte, error) {
+	var compressor interface {
+		io.WriteCloser
+		Reset(io.Writer)
+	}
+
+	switch w.opts.Compression {
+	case GELFGzip:
+		if w.gzip == nil {
+			w.gzip = gzip.NewWriter(nil)
+		}
+		compressor = w.gzip
+	case GELFZlib:
+		if w.zlib == nil {
+			w.zlib = zlib.NewWriter(nil)
+		}
+		compressor = w.zlib
+	default:
+		return message, nil
+	}
+
+	w.buf.Reset()
+	compressor.Reset(&w.buf)
+	if _, err := compressor.Write(message); err != nil {
+		return nil, err
+	}
+
+	if err := compressor.Close(); err != nil {
+		return nil, err
+	}
+
+	return w.buf.Bytes(), nil
+}
+
+// writeTCP writes the null-byte framed "message",
+// the connection is dialed again once if the write fails.
+func (w *GELFOutput) writeTCP(message []byte) error {
+	framed := make([]byte, len(message)+1)
+	copy(framed, message)
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			if w.conn, err = net.Dial(w.network, w.address); err != nil {
+				return err
+			}
+		}
+
+		if _, err = w.conn.Write(framed); err == nil {
+			return nil
+		}
+
+		w.conn.Close()
+		w.conn = nil
+	}
+
+	return err
+}
+
+// Close closes the connection.
+func (w *GELFOutput) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
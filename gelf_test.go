@@ -0,0 +1,214 @@
+package golog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGELFOutputUDPChunks(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	const chunkSize = 100
+	output, err := NewGELFOutput("udp", conn.LocalAddr().String(), GELFOptions{ChunkSize: chunkSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	logger := New().SetOutput(output).SetFormat("gelf")
+	message := strings.Repeat("a long message ", 30)
+	logger.Info(message)
+
+	var (
+		chunks = make(map[byte][]byte)
+		id     []byte
+		count  byte
+	)
+
+	buf := make([]byte, 2*chunkSize)
+	for count == 0 || len(chunks) < int(count) {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("read chunk %d of %d: %v", len(chunks), count, err)
+		}
+
+		chunk := buf[:n]
+		if n > chunkSize {
+			t.Fatalf("expected chunks of %d bytes at most but got %d", chunkSize, n)
+		}
+		if chunk[0] != 0x1e || chunk[1] != 0x0f {
+			t.Fatalf("expected the chunk magic bytes 0x1e 0x0f but got %#x %#x", chunk[0], chunk[1])
+		}
+
+		if id == nil {
+			id, count = bytes.Clone(chunk[2:10]), chunk[11]
+		} else if !bytes.Equal(chunk[2:10], id) || chunk[11] != count {
+			t.Fatalf("expected the message id %x of %d chunks but got %x of %d", id, count, chunk[2:10], chunk[11])
+		}
+
+		if chunk[10] >= count {
+			t.Fatalf("chunk sequence %d out of %d", chunk[10], count)
+		}
+		chunks[chunk[10]] = bytes.Clone(chunk[gelfChunkHeaderSize:])
+	}
+
+	if count < 2 {
+		t.Fatalf("expected the message to be chunked but got %d chunk", count)
+	}
+
+	var data []byte
+	for i := range count {
+		data = append(data, chunks[i]...)
+	}
+
+	var got map[string]any
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("decode reassembled message: %v: %s", err, data)
+	}
+
+	if got["short_message"] != message {
+		t.Fatalf("expected short_message %q but got %q", message, got["short_message"])
+	}
+}
+
+func TestGELFOutputUDPGzip(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	output, err := NewGELFOutput("udp", conn.LocalAddr().String(), GELFOptions{Compression: GELFGzip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	New().SetOutput(output).SetFormat("gelf").Infow("compressed", "user_id", 42)
+
+	buf := make([]byte, DefaultGELFChunkSize)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(buf[:n]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("decode message: %v: %s", err, data)
+	}
+
+	if got["short_message"] != "compressed" || got["_user_id"] != float64(42) {
+		t.Fatalf("unexpected message: %s", data)
+	}
+}
+
+func TestGELFOutputUDPTooLarge(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	const chunkSize = gelfChunkHeaderSize + 10
+	output, err := NewGELFOutput("udp", conn.LocalAddr().String(), GELFOptions{ChunkSize: chunkSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	// exactly 128 chunks.
+	if _, err = output.Write(bytes.Repeat([]byte("a"), gelfMaxChunks*10)); err != nil {
+		t.Fatalf("expected a message of %d chunks to be sent but got: %v", gelfMaxChunks, err)
+	}
+
+	if _, err = output.Write(bytes.Repeat([]byte("a"), gelfMaxChunks*10+1)); !errors.Is(err, ErrGELFMessageTooLarge) {
+		t.Fatalf("expected ErrGELFMessageTooLarge but got: %v", err)
+	}
+}
+
+func TestGELFOutputTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+
+		var messages []string
+		r := bufio.NewReader(conn)
+		for len(messages) < 2 {
+			message, err := r.ReadString(0)
+			if err != nil {
+				break
+			}
+			messages = append(messages, strings.TrimSuffix(message, "\x00"))
+		}
+		received <- messages
+	}()
+
+	output, err := NewGELFOutput("tcp", ln.Addr().String(), GELFOptions{Compression: GELFGzip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	logger := New().SetOutput(output).SetFormat("gelf")
+	logger.Info("first")
+	logger.Error("second")
+
+	var messages []string
+	select {
+	case messages = <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 null-byte framed messages but got %d", len(messages))
+	}
+
+	for i, expected := range []string{"first", "second"} {
+		var got map[string]any
+		// TCP messages are never compressed.
+		if err = json.Unmarshal([]byte(messages[i]), &got); err != nil {
+			t.Fatalf("decode message %d: %v: %q", i, err, messages[i])
+		}
+		if got["short_message"] != expected {
+			t.Fatalf("expected short_message %q but got %q", expected, got["short_message"])
+		}
+		if strings.ContainsRune(messages[i], '\n') {
+			t.Fatalf("expected the trailing new line to be removed: %q", messages[i])
+		}
+	}
+}
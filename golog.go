@@ -1,6 +1,7 @@
 package golog
 
 import (
+	"context"
 	"io"
 	"time"
 )
@@ -57,6 +58,44 @@ func SetStacktraceLimit(limit int) *Logger {
 	return Default.SetStacktraceLimit(limit)
 }
 
+// SetReportCaller sets whether the location of the log's caller
+// should be recorded on all levels of the default logger.
+// See `Logger.SetReportCaller` for more.
+func SetReportCaller(report bool) *Logger {
+	return Default.SetReportCaller(report)
+}
+
+// SetCallerSkip sets the number of additional stack frames
+// to skip when reporting the caller of the default logger.
+func SetCallerSkip(skip int) *Logger {
+	return Default.SetCallerSkip(skip)
+}
+
+// SetCallerFullPath sets whether the text output of the default logger
+// should print the full path of the caller's file instead of its base name.
+func SetCallerFullPath(fullPath bool) *Logger {
+	return Default.SetCallerFullPath(fullPath)
+}
+
+// SetSortFields sets whether the fields of the default logger should be sorted by key.
+// By default they keep the order they were added.
+func SetSortFields(sort bool) *Logger {
+	return Default.SetSortFields(sort)
+}
+
+// SetStacktraceLevel sets the least severe level, by its name,
+// which records the stacktrace on the default logger.
+// See `Logger.SetStacktraceLevel` for more.
+func SetStacktraceLevel(levelName string) *Logger {
+	return Default.SetStacktraceLevel(levelName)
+}
+
+// SetErrorStacktrace sets whether the stack carried by an error field
+// should be recorded instead of the log's call site one on the default logger.
+func SetErrorStacktrace(fromError bool) *Logger {
+	return Default.SetErrorStacktrace(fromError)
+}
+
 // RegisterFormatter registers a Formatter for this logger.
 func RegisterFormatter(f Formatter) *Logger {
 	return Default.RegisterFormatter(f)
@@ -173,6 +212,112 @@ func Debugf(format string, args ...any) {
 	Default.Debugf(format, args...)
 }
 
+// Logw prints a leveled log message with fields to the output.
+// See `Logger.Logw` for more.
+func Logw(level Level, msg string, kv ...any) {
+	Default.Logw(level, msg, kv...)
+}
+
+// LogFields prints a leveled log message with typed fields to the output.
+// See `Logger.LogFields` for more.
+func LogFields(level Level, msg string, fields ...Field) {
+	Default.LogFields(level, msg, fields...)
+}
+
+// Logfn prints a leveled log message which is built by the "fn" function,
+// only when the level is enabled. See `Logger.Logfn` for more.
+func Logfn(level Level, fn func() string) {
+	Default.Logfn(level, fn)
+}
+
+// Fatalfn same as `Logfn` with the `FatalLevel`, it will `os.Exit(1)` no matter the level of the logger.
+func Fatalfn(fn func() string) {
+	Default.Fatalfn(fn)
+}
+
+// Errorfn same as `Logfn` with the `ErrorLevel`.
+func Errorfn(fn func() string) {
+	Default.Errorfn(fn)
+}
+
+// Warnfn same as `Logfn` with the `WarnLevel`.
+func Warnfn(fn func() string) {
+	Default.Warnfn(fn)
+}
+
+// Infofn same as `Logfn` with the `InfoLevel`.
+func Infofn(fn func() string) {
+	Default.Infofn(fn)
+}
+
+// Debugfn same as `Logfn` with the `DebugLevel`.
+func Debugfn(fn func() string) {
+	Default.Debugfn(fn)
+}
+
+// Fatalw same as `Logw` with the `FatalLevel`, it will `os.Exit(1)` no matter the level of the logger.
+func Fatalw(msg string, kv ...any) {
+	Default.Fatalw(msg, kv...)
+}
+
+// Errorw same as `Logw` with the `ErrorLevel`.
+func Errorw(msg string, kv ...any) {
+	Default.Errorw(msg, kv...)
+}
+
+// Warnw same as `Logw` with the `WarnLevel`.
+func Warnw(msg string, kv ...any) {
+	Default.Warnw(msg, kv...)
+}
+
+// Infow same as `Logw` with the `InfoLevel`.
+func Infow(msg string, kv ...any) {
+	Default.Infow(msg, kv...)
+}
+
+// Debugw same as `Logw` with the `DebugLevel`.
+func Debugw(msg string, kv ...any) {
+	Default.Debugw(msg, kv...)
+}
+
+// LogContext prints a leveled log message to the output, with a context.
+// See `Logger.LogContext` for more.
+func LogContext(ctx context.Context, level Level, v ...any) {
+	Default.LogContext(ctx, level, v...)
+}
+
+// FatalContext same as `Fatal` but it accepts a context too.
+func FatalContext(ctx context.Context, v ...any) {
+	Default.FatalContext(ctx, v...)
+}
+
+// ErrorContext same as `Error` but it accepts a context too.
+func ErrorContext(ctx context.Context, v ...any) {
+	Default.ErrorContext(ctx, v...)
+}
+
+// WarnContext same as `Warn` but it accepts a context too.
+func WarnContext(ctx context.Context, v ...any) {
+	Default.WarnContext(ctx, v...)
+}
+
+// InfoContext same as `Info` but it accepts a context too.
+func InfoContext(ctx context.Context, v ...any) {
+	Default.InfoContext(ctx, v...)
+}
+
+// DebugContext same as `Debug` but it accepts a context too.
+func DebugContext(ctx context.Context, v ...any) {
+	Default.DebugContext(ctx, v...)
+}
+
+// AddContextExtractor registers a function which pulls fields
+// out of the context passed to the `*Context` functions of the default logger.
+// See `Logger.AddContextExtractor` for more.
+func AddContextExtractor(extractor ContextExtractor) *Logger {
+	return Default.AddContextExtractor(extractor)
+}
+
 // Install receives  an external logger
 // and automatically adapts its print functions.
 //
@@ -234,6 +379,13 @@ func Child(key any) *Logger {
 	return Default.Child(key)
 }
 
+// With returns a derived Logger of the default logger
+// which attaches the given fields to every log it prints.
+// See `Logger.With` for more.
+func With(args ...any) *Logger {
+	return Default.With(args...)
+}
+
 // SetChildPrefix same as `SetPrefix` but it does NOT
 // override the existing, instead the given "s"
 // is appended to the current one. It's useful
@@ -0,0 +1,309 @@
+package golog
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kataras/golog/printer"
+)
+
+// HTMLFormatter is a Formatter type for HTML reports, see `NewHTMLOutput`.
+// Each log is written as a table row, colored by its level,
+// with its fields and stacktrace in collapsible sections.
+type HTMLFormatter struct{}
+
+// String returns the name of the Formatter.
+// In this case it returns "html".
+// It's used to map the formatter names with their implementations.
+func (f *HTMLFormatter) String() string {
+	return "html"
+}
+
+// Options returns a new HTML Formatter, it accepts no options.
+func (f *HTMLFormatter) Options(opts ...any) Formatter {
+	return new(HTMLFormatter)
+}
+
+// htmlRowPrefix is the beginning of each row written by the HTML Formatter.
+const htmlRowPrefix = `<tr class="log"`
+
+// Format prints the logs as HTML table rows.
+//
+// Usage:
+// logger.SetOutput(htmlOutput).SetFormat("html") or
+// logger.SetLevelFormat("info", "html")
+func (f *HTMLFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	var (
+		level  = log.Level.String()
+		prefix = log.Prefix()
+	)
+
+	buf := append(*bufPtr, htmlRowPrefix...)
+	buf = append(buf, ` data-level="`...)
+	buf = appendHTML(buf, level)
+	buf = append(buf, `" data-prefix="`...)
+	buf = appendHTML(buf, prefix)
+	buf = append(buf, `" style="--level:`...)
+	buf = append(buf, htmlLevelColor(log.Level)...)
+	buf = append(buf, `"><td class="time">`...)
+	buf = log.Time.AppendFormat(buf, "2006-01-02 15:04:05.000")
+	buf = append(buf, `</td><td class="level">`...)
+	buf = appendHTML(buf, strings.ToUpper(level))
+	buf = append(buf, `</td><td class="prefix">`...)
+	buf = appendHTML(buf, prefix)
+	buf = append(buf, `</td><td class="message"><div class="text">`...)
+	buf = appendHTML(buf, log.Message)
+	buf = append(buf, `</div>`...)
+
+	if !log.Caller.IsZero() {
+		buf = append(buf, `<div class="caller">`...)
+		buf = appendHTML(buf, log.Caller.Source)
+		buf = append(buf, `</div>`...)
+	}
+
+	if len(log.Fields) > 0 {
+		buf = append(buf, `<details><summary>fields</summary><table class="fields">`...)
+		buf = appendHTMLFields(buf, "", log.Fields)
+		buf = append(buf, `</table></details>`...)
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = append(buf, `<details><summary>stacktrace</summary><pre>`...)
+		buf = appendHTML(buf, stacktraceString(log.Stacktrace))
+		buf = append(buf, `</pre></details>`...)
+	}
+
+	buf = append(buf, "</td></tr>\n"...)
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+// appendHTMLFields appends a table row per field, nested fields are written with dotted keys.
+func appendHTMLFields(buf []byte, prefix string, fields []Field) []byte {
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			buf = appendHTMLFields(buf, prefix+field.Key+".", field.Value.Group())
+			continue
+		}
+
+		value := fieldString(field.Value)
+		if info, ok := field.Value.Any().(*ErrorInfo); ok && field.Value.Kind() == slog.KindAny {
+			var b strings.Builder
+			b.WriteString(info.Type + ": " + info.Message)
+			writeCauses(&b, info.Causes, 1)
+			value = b.String()
+		}
+
+		buf = append(buf, `<tr><th>`...)
+		buf = appendHTML(buf, prefix+field.Key)
+		buf = append(buf, `</th><td>`...)
+		buf = appendHTML(buf, value)
+		buf = append(buf, `</td></tr>`...)
+	}
+
+	return buf
+}
+
+func appendHTML(buf []byte, s string) []byte {
+	return append(buf, html.EscapeString(s)...)
+}
+
+// htmlLevelColor returns the CSS color of the level's `ColorCode`.
+func htmlLevelColor(level Level) string {
+	meta, ok := Levels[level]
+	if !ok {
+		return "#888"
+	}
+
+	code := meta.ColorCode
+	if code >= 90 && code <= 97 { // bright colors.
+		code -= 60
+	}
+
+	switch code {
+	case printer.Black:
+		return "#444"
+	case printer.Red:
+		return "#d9534f"
+	case printer.Green:
+		return "#2e9e44"
+	case printer.Yellow:
+		return "#c99a00"
+	case printer.Blue:
+		return "#2f6fdd"
+	case printer.Magenta:
+		return "#b04fc0"
+	case printer.Cyan:
+		return "#1c9fb0"
+	default:
+		return "#888"
+	}
+}
+
+// HTMLOutput is an `io.WriteCloser` which writes a standalone HTML report
+// of the logs, with client-side filtering by level, text and prefix.
+// The document is readable while it's being written,
+// `Close` finalizes it and closes the underlying writer, if it's a closer.
+// The logs should be written by the "html" formatter, any other data
+// is written as a preformatted row.
+//
+// Usage:
+//
+//	file, err := os.Create("logs.html")
+//	output, err := golog.NewHTMLOutput(file, "integration tests")
+//	logger.SetOutput(output).SetFormat("html")
+//	defer output.Close()
+type HTMLOutput struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closed bool
+}
+
+// NewHTMLOutput returns a new HTML report output which writes to "w",
+// the "title" is the title of the document.
+func NewHTMLOutput(w io.Writer, title string) (*HTMLOutput, error) {
+	head := strings.Replace(htmlReportHead, "{{title}}", html.EscapeString(title), 2)
+	if _, err := io.WriteString(w, head); err != nil {
+		return nil, err
+	}
+
+	return &HTMLOutput{w: w}, nil
+}
+
+// Write writes the "p" row to the report,
+// data which is not a row of the "html" formatter is escaped and written as a row.
+func (w *HTMLOutput) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	if bytes.HasPrefix(p, []byte(htmlRowPrefix)) {
+		return w.w.Write(p)
+	}
+
+	text := strings.TrimRight(string(p), "\n")
+	if text == "" {
+		return len(p), nil
+	}
+
+	row := `<tr class="log" data-level="" data-prefix=""><td colspan="3"></td><td class="message"><pre>` +
+		html.EscapeString(text) + "</pre></td></tr>\n"
+	if _, err := io.WriteString(w.w, row); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close finalizes the document and closes the underlying writer, if it's a closer.
+func (w *HTMLOutput) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	_, err := io.WriteString(w.w, htmlReportFoot)
+	if closer, ok := w.w.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// htmlReportHead is the beginning of the report, the rows are written inside its table's body.
+// The script runs once the document, finalized or not, is loaded.
+const htmlReportHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="generator" content="golog">
+<title>{{title}}</title>
+<style>
+body { margin: 0; font: 13px/1.4 ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; color: #222; background: #fafafa; }
+header { position: sticky; top: 0; display: flex; gap: 8px; align-items: center; padding: 8px 12px; background: #fff; border-bottom: 1px solid #ddd; }
+header h1 { margin: 0 auto 0 0; font-size: 15px; }
+header input, header select { font: inherit; padding: 2px 4px; }
+table.logs { width: 100%; border-collapse: collapse; }
+table.logs th { text-align: left; padding: 4px 8px; background: #eee; }
+tr.log > td { padding: 3px 8px; border-bottom: 1px solid #eee; vertical-align: top; }
+tr.log { border-left: 4px solid var(--level, #888); }
+tr.log > td.time, tr.log > td.level, tr.log > td.prefix { white-space: nowrap; color: #666; }
+tr.log > td.level { color: var(--level, #888); font-weight: bold; }
+.text, pre { margin: 0; white-space: pre-wrap; word-break: break-word; }
+.caller { color: #888; }
+details summary { cursor: pointer; color: #666; }
+table.fields th { text-align: left; padding-right: 12px; color: #555; font-weight: normal; vertical-align: top; }
+table.fields td { white-space: pre-wrap; }
+</style>
+<script>
+document.addEventListener("DOMContentLoaded", function () {
+  var rows = Array.prototype.slice.call(document.querySelectorAll("tr.log"));
+  var level = document.getElementById("level"), prefix = document.getElementById("prefix");
+  var text = document.getElementById("text"), count = document.getElementById("count");
+  function fill(select, attr) {
+    var seen = {};
+    rows.forEach(function (row) {
+      var value = row.getAttribute(attr);
+      if (value && !seen[value]) {
+        seen[value] = true;
+        var option = document.createElement("option");
+        option.value = option.textContent = value;
+        select.appendChild(option);
+      }
+    });
+  }
+  function filter() {
+    var l = level.value, p = prefix.value, t = text.value.toLowerCase(), shown = 0;
+    rows.forEach(function (row) {
+      var show = (!l || row.getAttribute("data-level") === l) &&
+        (!p || row.getAttribute("data-prefix") === p) &&
+        (!t || row.textContent.toLowerCase().indexOf(t) >= 0);
+      row.hidden = !show;
+      if (show) shown++;
+    });
+    count.textContent = shown + " / " + rows.length;
+  }
+  fill(level, "data-level");
+  fill(prefix, "data-prefix");
+  level.onchange = prefix.onchange = text.oninput = filter;
+  filter();
+});
+</script>
+</head>
+<body>
+<header>
+<h1>{{title}}</h1>
+<select id="level"><option value="">all levels</option></select>
+<select id="prefix"><option value="">all prefixes</option></select>
+<input id="text" type="search" placeholder="filter">
+<span id="count"></span>
+</header>
+<table class="logs">
+<thead><tr><th>Time</th><th>Level</th><th>Prefix</th><th>Message</th></tr></thead>
+<tbody>
+`
+
+// htmlReportFoot closes the report's document.
+const htmlReportFoot = `</tbody>
+</table>
+</body>
+</html>
+`
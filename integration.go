@@ -36,18 +36,19 @@ func (*slog.Logger).WithGroup(name string) *slog.Logger
 */
 func integrateSlog(logger *slog.Logger) Handler {
 	return func(log *Log) bool {
+		ctx := log.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
 		// golog level to slog level.
 		level := getSlogLevel(log.Level)
 		// golog fields to slog attributes.
 		if len(log.Fields) > 0 {
-			attrs := make([]slog.Attr, 0, len(log.Fields))
-			for k, v := range log.Fields {
-				attrs = append(attrs, slog.Any(k, v))
-			}
+			attrs := []slog.Attr(log.Fields)
 			// log the message with attrs.
-			logger.LogAttrs(context.Background(), level, log.Message, attrs...)
+			logger.LogAttrs(ctx, level, log.Message, attrs...)
 		} else {
-			logger.Log(context.Background(), level, log.Message)
+			logger.Log(ctx, level, log.Message)
 		}
 
 		return true
@@ -98,6 +99,56 @@ func getSlogLevel(level Level) slog.Level {
 	return slog.LevelDebug
 }
 
+func getGologLevel(level slog.Level) Level {
+	switch level {
+	case slog.LevelError:
+		return ErrorLevel
+	case slog.LevelWarn:
+		return WarnLevel
+	case slog.LevelInfo:
+		return InfoLevel
+	case slog.LevelDebug:
+		return DebugLevel
+	}
+
+	// a custom slog level is mapped to the registered level of the same
+	// OpenTelemetry severity number, i.e. the slog level + 9, fatal excluded.
+	if custom, ok := levelBySeverity(int(level) + 9); ok {
+		return custom
+	}
+
+	switch {
+	case level >= slog.LevelError:
+		return ErrorLevel
+	case level >= slog.LevelWarn:
+		return WarnLevel
+	case level >= slog.LevelInfo:
+		return InfoLevel
+	}
+	return DebugLevel
+}
+
+// levelBySeverity returns the registered level, except the fatal and disable ones,
+// of the given `LevelMetadata.SeverityNumber`, the lowest one on more matches.
+func levelBySeverity(severity int) (Level, bool) {
+	var (
+		found Level
+		ok    bool
+	)
+
+	for level, meta := range Levels {
+		if level == DisableLevel || level == FatalLevel || meta.SeverityNumber != severity {
+			continue
+		}
+
+		if !ok || level < found {
+			found, ok = level, true
+		}
+	}
+
+	return found, ok
+}
+
 func getExternalPrintFunc(logger ExternalLogger, log *Log) func(...any) {
 	switch log.Level {
 	case ErrorLevel:
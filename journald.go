@@ -0,0 +1,164 @@
+package golog
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultJournaldAddress is the socket of the systemd-journald native protocol.
+const DefaultJournaldAddress = "/run/systemd/journal/socket"
+
+// ErrJournaldUnsupported is returned by `NewJournaldOutput`
+// on operating systems other than linux.
+var ErrJournaldUnsupported = errors.New("golog: journald: unsupported operating system")
+
+// JournaldFormatter is a Formatter type for the systemd-journald native protocol.
+// It writes the `MESSAGE`, the `PRIORITY` as the syslog severity of the log's level,
+// the `SYSLOG_IDENTIFIER`, the `CODE_FILE`, `CODE_LINE` and `CODE_FUNC` of the caller,
+// the `LOGGER` as the Logger's prefix, the `STACKTRACE` and each field as an uppercase
+// journal field, nested keys are joined by an underscore, e.g. "http.method" as `HTTP_METHOD`.
+// Fields whose names are written by the formatter itself are prefixed by `FIELD_`,
+// e.g. a "message" field is written as `FIELD_MESSAGE`.
+//
+// Pair it with a `JournaldOutput` to send the logs to the journal.
+type JournaldFormatter struct {
+	// SyslogIdentifier is the identifier of the logs,
+	// defaults to the base name of the executable.
+	SyslogIdentifier string
+}
+
+// String returns the name of the Formatter.
+// In this case it returns "journald".
+// It's used to map the formatter names with their implementations.
+func (f *JournaldFormatter) String() string {
+	return "journald"
+}
+
+// Options sets the options for the journald Formatter and returns a new one.
+// A string option sets the `SyslogIdentifier`.
+func (f *JournaldFormatter) Options(opts ...any) Formatter {
+	formatter := &JournaldFormatter{SyslogIdentifier: f.SyslogIdentifier}
+	for _, opt := range opts {
+		if identifier, ok := opt.(string); ok {
+			formatter.SyslogIdentifier = identifier
+		}
+	}
+
+	return formatter
+}
+
+// Format prints the logs in the journald native protocol format.
+//
+// Usage:
+// logger.SetFormat("journald") or
+// logger.SetLevelFormat("error", "journald")
+func (f *JournaldFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	identifier := f.SyslogIdentifier
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+
+	buf := appendJournaldField(*bufPtr, "MESSAGE", log.Message)
+	buf = appendJournaldField(buf, "PRIORITY", strconv.Itoa(log.Level.syslogSeverity()))
+	buf = appendJournaldField(buf, "SYSLOG_IDENTIFIER", identifier)
+
+	if !log.Caller.IsZero() {
+		buf = appendJournaldField(buf, "CODE_FILE", log.Caller.File)
+		buf = appendJournaldField(buf, "CODE_LINE", strconv.Itoa(log.Caller.Line))
+		buf = appendJournaldField(buf, "CODE_FUNC", log.Caller.Function)
+	}
+
+	if prefix := log.Prefix(); prefix != "" {
+		buf = appendJournaldField(buf, "LOGGER", prefix)
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = appendJournaldField(buf, "STACKTRACE", stacktraceString(log.Stacktrace))
+	}
+
+	buf = appendJournaldFields(buf, "", log.Fields)
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+func appendJournaldFields(buf []byte, prefix string, fields FieldList) []byte {
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			buf = appendJournaldFields(buf, prefix+field.Key+"_", field.Value.Group())
+			continue
+		}
+
+		if name := journaldUserFieldName(prefix + field.Key); name != "" {
+			buf = appendJournaldField(buf, name, field.Value.String())
+		}
+	}
+
+	return buf
+}
+
+// appendJournaldField appends a "name=value" line,
+// a value of more lines is written with its length as a little-endian 64-bit integer.
+func appendJournaldField(buf []byte, name, value string) []byte {
+	buf = append(buf, name...)
+	if strings.ContainsRune(value, '\n') {
+		buf = append(buf, '\n')
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(len(value)))
+	} else {
+		buf = append(buf, '=')
+	}
+	buf = append(buf, value...)
+	return append(buf, '\n')
+}
+
+// journaldReservedFields are the journal fields which are written by the formatter itself.
+var journaldReservedFields = map[string]struct{}{
+	"MESSAGE":           {},
+	"PRIORITY":          {},
+	"SYSLOG_IDENTIFIER": {},
+	"CODE_FILE":         {},
+	"CODE_LINE":         {},
+	"CODE_FUNC":         {},
+	"LOGGER":            {},
+	"STACKTRACE":        {},
+}
+
+// journaldUserFieldName returns the journal field name of a field's key,
+// prefixed by "FIELD_" when it collides with a reserved one.
+func journaldUserFieldName(key string) string {
+	name := journaldFieldName(key)
+	if _, reserved := journaldReservedFields[name]; reserved {
+		return journaldFieldName("FIELD_" + name)
+	}
+
+	return name
+}
+
+// journaldFieldName returns the journal field name of a key:
+// upper case letters, digits and underscores, up to 64 characters,
+// which does not start with an underscore or a digit.
+func journaldFieldName(key string) string {
+	name := []byte(strings.ToUpper(key))
+	for i, c := range name {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			name[i] = '_'
+		}
+	}
+
+	s := strings.TrimLeft(string(name), "_0123456789")
+	if len(s) > 64 {
+		s = s[:64]
+	}
+
+	return s
+}
@@ -0,0 +1,105 @@
+//go:build linux
+
+package golog
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// JournaldOutput is an `io.Writer` which sends the logs to systemd-journald
+// through its native protocol, over a unix datagram socket.
+// Each Write sends one entry, as written by the `JournaldFormatter`.
+// An entry which is too large for a datagram is written to a sealed memory file
+// and its file descriptor is passed to the journal instead.
+//
+// Usage:
+//
+//	output, err := golog.NewJournaldOutput("")
+//	logger.SetOutput(output).SetFormat("journald")
+type JournaldOutput struct {
+	mu   sync.Mutex
+	conn *net.UnixConn
+	addr *net.UnixAddr
+}
+
+// NewJournaldOutput returns a new journald output which sends the entries
+// to the given socket, the `DefaultJournaldAddress` if "address" is empty.
+func NewJournaldOutput(address string) (*JournaldOutput, error) {
+	if address == "" {
+		address = DefaultJournaldAddress
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	w := &JournaldOutput{
+		conn: conn,
+		addr: &net.UnixAddr{Name: address, Net: "unixgram"},
+	}
+
+	return w, nil
+}
+
+// Write sends "p" as one journal entry.
+func (w *JournaldOutput) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, _, err := w.conn.WriteMsgUnix(p, nil, w.addr)
+	if err == nil {
+		return len(p), nil
+	}
+
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return 0, err
+	}
+
+	if err = w.writeFile(p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// writeFile writes "p" to a sealed memory file and passes its file descriptor to the journal.
+func (w *JournaldOutput) writeFile(p []byte) error {
+	fd, err := unix.MemfdCreate("golog-journald", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return err
+	}
+
+	file := os.NewFile(uintptr(fd), "golog-journald")
+	defer file.Close()
+
+	if _, err = file.Write(p); err != nil {
+		return err
+	}
+
+	const seals = unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err = unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, seals); err != nil {
+		return err
+	}
+
+	_, _, err = w.conn.WriteMsgUnix(nil, unix.UnixRights(int(file.Fd())), w.addr)
+	return err
+}
+
+// Close closes the socket.
+func (w *JournaldOutput) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.conn.Close()
+}
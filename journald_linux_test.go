@@ -0,0 +1,176 @@
+//go:build linux
+
+package golog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// parseJournaldEntry decodes a journald native protocol entry.
+func parseJournaldEntry(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	entry := make(map[string]string)
+	for len(data) > 0 {
+		i := bytes.IndexAny(data, "=\n")
+		if i < 0 {
+			t.Fatalf("unterminated field: %q", data)
+		}
+
+		name := string(data[:i])
+		if data[i] == '=' {
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				t.Fatalf("unterminated value of %s", name)
+			}
+			entry[name] = string(data[i+1 : i+end])
+			data = data[i+end+1:]
+			continue
+		}
+
+		// binary-safe: a little-endian 64-bit length, the value and a new line.
+		data = data[i+1:]
+		if len(data) < 8 {
+			t.Fatalf("missing length of %s", name)
+		}
+		n := binary.LittleEndian.Uint64(data)
+		data = data[8:]
+		if uint64(len(data)) < n+1 || data[n] != '\n' {
+			t.Fatalf("invalid length %d of %s", n, name)
+		}
+		entry[name] = string(data[:n])
+		data = data[n+1:]
+	}
+
+	return entry
+}
+
+func listenJournald(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+
+	address := filepath.Join(t.TempDir(), "journal.socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: address, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, address
+}
+
+func TestJournaldOutput(t *testing.T) {
+	conn, address := listenJournald(t)
+
+	output, err := NewJournaldOutput(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	logger := New().SetOutput(output).SetPrefix("http: ")
+	logger.SetFormat("journald", "app")
+	logger.Warnw("multi\nline", "message", "a field", "binary", "a\x00b\nc", "http.method", "GET")
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := parseJournaldEntry(t, buf[:n])
+	expected := map[string]string{
+		"MESSAGE":           "multi\nline",
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "app",
+		"LOGGER":            "http",
+		"FIELD_MESSAGE":     "a field",
+		"BINARY":            "a\x00b\nc",
+		"HTTP_METHOD":       "GET",
+	}
+
+	for name, value := range expected {
+		if entry[name] != value {
+			t.Errorf("expected %s=%q but got %q", name, value, entry[name])
+		}
+	}
+
+	if len(entry) != len(expected) {
+		t.Errorf("expected %d fields but got %d: %q", len(expected), len(entry), entry)
+	}
+}
+
+func TestJournaldOutputMemfd(t *testing.T) {
+	conn, address := listenJournald(t)
+
+	output, err := NewJournaldOutput(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	// larger than the maximum datagram size.
+	message := strings.Repeat("a", 8<<20)
+	New().SetOutput(output).SetFormat("journald", "app").Info(message)
+
+	oob := make([]byte, unix.CmsgSpace(4))
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, oobn, _, _, err := conn.ReadMsgUnix(nil, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 0 {
+		t.Fatalf("expected an empty datagram but got %d bytes", n)
+	}
+
+	messages, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("expected one control message but got %d: %v", len(messages), err)
+	}
+
+	fds, err := unix.ParseUnixRights(&messages[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("expected one file descriptor but got %d: %v", len(fds), err)
+	}
+
+	file := os.NewFile(uintptr(fds[0]), "journald-entry")
+	defer file.Close()
+
+	seals, err := unix.FcntlInt(file.Fd(), unix.F_GET_SEALS, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL; seals&expected != expected {
+		t.Fatalf("expected the memfd to be sealed but got seals %#x", seals)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(io.NewSectionReader(file, 0, info.Size()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := parseJournaldEntry(t, data)
+	if entry["MESSAGE"] != message {
+		t.Fatalf("expected a MESSAGE of %d bytes but got %d", len(message), len(entry["MESSAGE"]))
+	}
+
+	if entry["PRIORITY"] != "6" || entry["SYSLOG_IDENTIFIER"] != "app" {
+		t.Fatalf("unexpected entry fields: PRIORITY=%q SYSLOG_IDENTIFIER=%q", entry["PRIORITY"], entry["SYSLOG_IDENTIFIER"])
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package golog
+
+// JournaldOutput is an `io.Writer` which sends the logs to systemd-journald,
+// it's available on linux only.
+type JournaldOutput struct{}
+
+// NewJournaldOutput returns the ErrJournaldUnsupported error,
+// systemd-journald is available on linux only.
+func NewJournaldOutput(address string) (*JournaldOutput, error) {
+	return nil, ErrJournaldUnsupported
+}
+
+// Write returns the ErrJournaldUnsupported error.
+func (w *JournaldOutput) Write(p []byte) (int, error) {
+	return 0, ErrJournaldUnsupported
+}
+
+// Close does nothing.
+func (w *JournaldOutput) Close() error {
+	return nil
+}
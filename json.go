@@ -0,0 +1,231 @@
+package golog
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// The JSON encoding of the formatters. The values are appended to a byte buffer,
+// the common types are encoded without reflection and the same way
+// as the `encoding/json` package does, the rest through `json.Marshal`.
+
+// appendJSON appends the JSON encoding of "v".
+func appendJSON(buf []byte, v any) []byte {
+	if s, ok := v.(string); ok {
+		return appendJSONString(buf, s)
+	}
+
+	return appendJSONValue(buf, fieldValue(v))
+}
+
+// appendJSONValue appends the JSON encoding of a field's value,
+// groups are encoded as nested objects.
+func appendJSONValue(buf []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return appendJSONString(buf, v.String())
+	case slog.KindInt64:
+		return strconv.AppendInt(buf, v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.AppendUint(buf, v.Uint64(), 10)
+	case slog.KindFloat64:
+		return appendJSONFloat(buf, v.Float64())
+	case slog.KindBool:
+		return strconv.AppendBool(buf, v.Bool())
+	case slog.KindDuration:
+		return strconv.AppendInt(buf, int64(v.Duration()), 10)
+	case slog.KindTime:
+		buf = append(buf, '"')
+		buf = v.Time().AppendFormat(buf, time.RFC3339Nano)
+		return append(buf, '"')
+	case slog.KindGroup:
+		return appendJSONFields(buf, v.Group())
+	default:
+		return appendJSONAny(buf, v.Any())
+	}
+}
+
+// appendJSONFields appends the fields as a JSON object, in order.
+func appendJSONFields(buf []byte, fields []Field) []byte {
+	buf = append(buf, '{')
+	for i, field := range fields {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONString(buf, field.Key)
+		buf = append(buf, ':')
+		buf = appendJSONValue(buf, field.Value)
+	}
+
+	return append(buf, '}')
+}
+
+// appendJSONAny appends the JSON encoding of the value of a `slog.KindAny` field,
+// a value which can't be encoded is written as its error string.
+func appendJSONAny(buf []byte, v any) []byte {
+	switch value := v.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case *ErrorInfo:
+		return appendJSONError(buf, value)
+	case []byte:
+		buf = append(buf, '"')
+		buf = base64.StdEncoding.AppendEncode(buf, value)
+		return append(buf, '"')
+	case []string:
+		if value == nil {
+			return append(buf, "null"...)
+		}
+		buf = append(buf, '[')
+		for i, s := range value {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONString(buf, s)
+		}
+		return append(buf, ']')
+	case []int:
+		if value == nil {
+			return append(buf, "null"...)
+		}
+		buf = append(buf, '[')
+		for i, n := range value {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = strconv.AppendInt(buf, int64(n), 10)
+		}
+		return append(buf, ']')
+	case []any:
+		if value == nil {
+			return append(buf, "null"...)
+		}
+		buf = append(buf, '[')
+		for i, elem := range value {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSON(buf, elem)
+		}
+		return append(buf, ']')
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return appendJSONString(buf, err.Error())
+	}
+
+	return append(buf, b...)
+}
+
+// appendJSONError appends the error as an object of its "message", "type" and "causes".
+func appendJSONError(buf []byte, info *ErrorInfo) []byte {
+	if info == nil {
+		return append(buf, "null"...)
+	}
+
+	buf = append(buf, `{"message":`...)
+	buf = appendJSONString(buf, info.Message)
+	buf = append(buf, `,"type":`...)
+	buf = appendJSONString(buf, info.Type)
+
+	if len(info.Causes) > 0 {
+		buf = append(buf, `,"causes":[`...)
+		for i, cause := range info.Causes {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONError(buf, cause)
+		}
+		buf = append(buf, ']')
+	}
+
+	return append(buf, '}')
+}
+
+// appendJSONFloat appends the float in the format of the `encoding/json` package,
+// NaN and infinite values are not valid JSON and are written as the "NaN", "+Inf" and "-Inf" strings.
+func appendJSONFloat(buf []byte, f float64) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(buf, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(buf, `"+Inf"`...)
+	case math.IsInf(f, -1):
+		return append(buf, `"-Inf"`...)
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+
+	buf = strconv.AppendFloat(buf, f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9.
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+
+	return buf
+}
+
+const jsonHex = "0123456789abcdef"
+
+// appendJSONString appends the quoted and escaped "s",
+// HTML characters are escaped too, like the `encoding/json` package does.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				buf = append(buf, '\\', b)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', jsonHex[b>>4], jsonHex[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+		case r == '\u2028' || r == '\u2029':
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', jsonHex[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
@@ -0,0 +1,42 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSONFloatNaNInf(t *testing.T) {
+	tests := []struct {
+		format string
+		fields func(got map[string]any) any
+	}{
+		{"json", func(got map[string]any) any { return got["fields"] }},
+		{"gelf", func(got map[string]any) any {
+			return map[string]any{"nan": got["_nan"], "pos": got["_pos"], "neg": got["_neg"], "value": got["_value"]}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New().SetOutput(&buf).SetFormat(tt.format)
+			logger.Infow("floats",
+				"nan", math.NaN(), "pos", math.Inf(1), "neg", float32(math.Inf(-1)), "value", 1.5)
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v: %s", err, buf.Bytes())
+			}
+
+			fields, _ := tt.fields(got).(map[string]any)
+			expected := map[string]any{"nan": "NaN", "pos": "+Inf", "neg": "-Inf", "value": 1.5}
+			for key, value := range expected {
+				if fields[key] != value {
+					t.Errorf("expected %s=%v but got %v", key, value, fields[key])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package golog
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONProfile is the field layout of the JSON Formatter.
+// The profiles write the field names which each cloud logging agent
+// parses natively, so the level, message, time, source location and trace
+// of the logs are recognized without any extra configuration.
+//
+// The fields whose keys are used by a profile are written under a "fields" object,
+// only the first trace and span fields are consumed.
+//
+// Usage:
+//
+//	logger.SetFormat("json", golog.JSONProfileGCP)
+type JSONProfile uint8
+
+const (
+	// JSONProfileDefault is the golog's own layout,
+	// the `Log` encoded as it is.
+	JSONProfileDefault JSONProfile = iota
+	// JSONProfileGCP is the layout of the Google Cloud Logging structured logs:
+	// "severity", "message", "time", "logging.googleapis.com/sourceLocation",
+	// "logging.googleapis.com/trace", "logging.googleapis.com/spanId" and
+	// "logging.googleapis.com/labels" (the Logger's prefix as "logger").
+	// The fields are written at the top level, the stacktrace as "stack_trace"
+	// in the format of a Go panic, so Error Reporting can group the errors.
+	JSONProfileGCP
+	// JSONProfileAWS is the layout of the AWS Lambda and CloudWatch Logs JSON logs:
+	// "timestamp", "level", "message", "logger" (the Logger's prefix) and
+	// "location" ("function:line"). The fields are written at the top level,
+	// the first error field as "errorType" and "errorMessage" and the stacktrace as "stackTrace".
+	JSONProfileAWS
+	// JSONProfileAzure is the layout of the Azure Monitor logs:
+	// "time", "level", "message", "category" (the Logger's prefix),
+	// "operation_Id" and "operation_ParentId" (the trace and span)
+	// and "properties", which holds the fields, the caller and the stacktrace.
+	JSONProfileAzure
+)
+
+// gcpSeverity returns the Google Cloud Logging `LogSeverity` of the level,
+// based on its `SeverityNumber`.
+func (l Level) gcpSeverity() string {
+	meta, ok := Levels[l]
+	if !ok {
+		return "DEFAULT"
+	}
+
+	switch n := meta.SeverityNumber; {
+	case n >= 21:
+		return "CRITICAL"
+	case n >= 17:
+		return "ERROR"
+	case n >= 13:
+		return "WARNING"
+	case n >= 9:
+		return "INFO"
+	case n > 0:
+		return "DEBUG"
+	default:
+		return "DEFAULT"
+	}
+}
+
+// awsLevel returns the AWS Lambda log level of the level,
+// based on its `SeverityNumber`. Levels without a severity number
+// are written as their upper-cased name.
+func (l Level) awsLevel() string {
+	meta, ok := Levels[l]
+	if !ok {
+		return ""
+	}
+
+	switch n := meta.SeverityNumber; {
+	case n >= 21:
+		return "FATAL"
+	case n >= 17:
+		return "ERROR"
+	case n >= 13:
+		return "WARN"
+	case n >= 9:
+		return "INFO"
+	case n >= 5:
+		return "DEBUG"
+	case n > 0:
+		return "TRACE"
+	default:
+		return strings.ToUpper(meta.Name)
+	}
+}
+
+// azureLevel returns the Azure Monitor level of the level,
+// based on its `SeverityNumber`.
+func (l Level) azureLevel() string {
+	meta, ok := Levels[l]
+	if !ok {
+		return "Informational"
+	}
+
+	switch n := meta.SeverityNumber; {
+	case n >= 21:
+		return "Critical"
+	case n >= 17:
+		return "Error"
+	case n >= 13:
+		return "Warning"
+	case n >= 9 || n == 0:
+		return "Informational"
+	default:
+		return "Verbose"
+	}
+}
+
+// gcpProjectID returns the Google Cloud project of the traces,
+// the formatter's `ProjectID` or the "GOOGLE_CLOUD_PROJECT" environment variable.
+func (f *JSONFormatter) gcpProjectID() string {
+	if f.ProjectID != "" {
+		return f.ProjectID
+	}
+
+	return os.Getenv("GOOGLE_CLOUD_PROJECT")
+}
+
+// formatProfile writes the "log" in the layout of the formatter's `Profile`, on a single line.
+func (f *JSONFormatter) formatProfile(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	buf := *bufPtr
+	switch f.Profile {
+	case JSONProfileGCP:
+		buf = f.appendGCP(buf, log)
+	case JSONProfileAWS:
+		buf = appendAWS(buf, log)
+	default:
+		buf = appendAzure(buf, log)
+	}
+	buf = append(buf, '\n')
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+func (f *JSONFormatter) appendGCP(buf []byte, log *Log) []byte {
+	buf = append(buf, `{"severity":"`...)
+	buf = append(buf, log.Level.gcpSeverity()...)
+	buf = append(buf, `","message":`...)
+	buf = appendJSON(buf, log.Message)
+	buf = append(buf, `,"time":"`...)
+	buf = log.Time.UTC().AppendFormat(buf, time.RFC3339Nano)
+	buf = append(buf, '"')
+
+	if !log.Caller.IsZero() {
+		buf = append(buf, `,"logging.googleapis.com/sourceLocation":{"file":`...)
+		buf = appendJSON(buf, log.Caller.File)
+		buf = append(buf, `,"line":"`...)
+		buf = strconv.AppendInt(buf, int64(log.Caller.Line), 10)
+		buf = append(buf, `","function":`...)
+		buf = appendJSON(buf, log.Caller.Function)
+		buf = append(buf, '}')
+	}
+
+	if prefix := log.Prefix(); prefix != "" {
+		buf = append(buf, `,"logging.googleapis.com/labels":{"logger":`...)
+		buf = appendJSON(buf, prefix)
+		buf = append(buf, '}')
+	}
+
+	var (
+		reserved          FieldList
+		hasTrace, hasSpan bool // only the first trace and span fields are consumed.
+	)
+
+	for _, field := range log.Fields {
+		switch {
+		case isTraceField(field.Key) && !hasTrace:
+			hasTrace = true
+			trace := field.Value.String()
+			if projectID := f.gcpProjectID(); projectID != "" {
+				trace = "projects/" + projectID + "/traces/" + trace
+			}
+			buf = append(buf, `,"logging.googleapis.com/trace":`...)
+			buf = appendJSON(buf, trace)
+		case isSpanField(field.Key) && !hasSpan:
+			hasSpan = true
+			buf = append(buf, `,"logging.googleapis.com/spanId":`...)
+			buf = appendJSON(buf, field.Value.String())
+		case field.Key == "severity", field.Key == "message", field.Key == "time",
+			field.Key == "stack_trace", field.Key == "fields",
+			strings.HasPrefix(field.Key, "logging.googleapis.com/"):
+			reserved = append(reserved, field)
+		default:
+			buf = appendProfileField(buf, field)
+		}
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = append(buf, `,"stack_trace":`...)
+		buf = appendJSON(buf, stacktraceString(log.Stacktrace))
+	}
+
+	return appendReservedFields(buf, reserved)
+}
+
+func appendAWS(buf []byte, log *Log) []byte {
+	buf = append(buf, `{"timestamp":"`...)
+	buf = log.Time.UTC().AppendFormat(buf, "2006-01-02T15:04:05.000Z07:00")
+	buf = append(buf, `","level":`...)
+	buf = appendJSON(buf, log.Level.awsLevel())
+	buf = append(buf, `,"message":`...)
+	buf = appendJSON(buf, log.Message)
+
+	if prefix := log.Prefix(); prefix != "" {
+		buf = append(buf, `,"logger":`...)
+		buf = appendJSON(buf, prefix)
+	}
+
+	if !log.Caller.IsZero() {
+		buf = append(buf, `,"location":`...)
+		buf = appendJSON(buf, log.Caller.Function+":"+strconv.Itoa(log.Caller.Line))
+	}
+
+	var (
+		reserved  FieldList
+		errorInfo *ErrorInfo
+	)
+
+	for _, field := range log.Fields {
+		if info, ok := field.Value.Any().(*ErrorInfo); ok && errorInfo == nil {
+			errorInfo = info
+			continue
+		}
+
+		switch field.Key {
+		case "timestamp", "level", "message", "logger", "location",
+			"errorType", "errorMessage", "stackTrace", "fields":
+			reserved = append(reserved, field)
+		default:
+			buf = appendProfileField(buf, field)
+		}
+	}
+
+	if errorInfo != nil {
+		buf = append(buf, `,"errorType":`...)
+		buf = appendJSON(buf, errorInfo.Type)
+		buf = append(buf, `,"errorMessage":`...)
+		buf = appendJSON(buf, errorInfo.Message)
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = append(buf, `,"stackTrace":[`...)
+		for i, frame := range log.Stacktrace {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSON(buf, frame.Function+" ("+frame.Source+")")
+		}
+		buf = append(buf, ']')
+	}
+
+	return appendReservedFields(buf, reserved)
+}
+
+func appendAzure(buf []byte, log *Log) []byte {
+	buf = append(buf, `{"time":"`...)
+	buf = log.Time.UTC().AppendFormat(buf, time.RFC3339Nano)
+	buf = append(buf, `","level":"`...)
+	buf = append(buf, log.Level.azureLevel()...)
+	buf = append(buf, `","message":`...)
+	buf = appendJSON(buf, log.Message)
+
+	if prefix := log.Prefix(); prefix != "" {
+		buf = append(buf, `,"category":`...)
+		buf = appendJSON(buf, prefix)
+	}
+
+	buf = append(buf, `,"properties":{`...)
+	start := len(buf)
+
+	var (
+		reserved          FieldList
+		traceID, spanID   string
+		hasTrace, hasSpan bool // only the first trace and span fields are consumed.
+	)
+
+	for _, field := range log.Fields {
+		switch {
+		case isTraceField(field.Key) && !hasTrace:
+			hasTrace, traceID = true, field.Value.String()
+		case isSpanField(field.Key) && !hasSpan:
+			hasSpan, spanID = true, field.Value.String()
+		case field.Key == "caller", field.Key == "stacktrace", field.Key == "fields":
+			reserved = append(reserved, field)
+		default:
+			buf = appendProfileField(buf, field)
+		}
+	}
+
+	if !log.Caller.IsZero() {
+		buf = append(buf, `,"caller":`...)
+		buf = appendJSON(buf, log.Caller.Source)
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = append(buf, `,"stacktrace":`...)
+		buf = appendJSON(buf, stacktraceString(log.Stacktrace))
+	}
+
+	if len(reserved) > 0 {
+		buf = append(buf, `,"fields":`...)
+		buf = appendJSONValue(buf, slog.GroupValue(reserved...))
+	}
+
+	if len(buf) > start {
+		// drop the leading comma of the first property.
+		buf = append(buf[:start], buf[start+1:]...)
+	}
+	buf = append(buf, '}')
+
+	if traceID != "" {
+		buf = append(buf, `,"operation_Id":`...)
+		buf = appendJSON(buf, traceID)
+	}
+
+	if spanID != "" {
+		buf = append(buf, `,"operation_ParentId":`...)
+		buf = appendJSON(buf, spanID)
+	}
+
+	return append(buf, '}')
+}
+
+// isTraceField reports whether the field of the "key" holds the trace id.
+func isTraceField(key string) bool {
+	return key == "trace_id" || key == "traceId"
+}
+
+// isSpanField reports whether the field of the "key" holds the span id.
+func isSpanField(key string) bool {
+	return key == "span_id" || key == "spanId"
+}
+
+// appendProfileField appends a comma, followed by the key and the value of the "field".
+func appendProfileField(buf []byte, field Field) []byte {
+	buf = append(buf, ',')
+	buf = appendJSON(buf, field.Key)
+	buf = append(buf, ':')
+	return appendJSONValue(buf, field.Value)
+}
+
+// appendReservedFields appends the fields whose keys are used by the profile
+// under a "fields" object and closes the log's object.
+func appendReservedFields(buf []byte, fields FieldList) []byte {
+	if len(fields) > 0 {
+		buf = append(buf, `,"fields":`...)
+		buf = appendJSONValue(buf, slog.GroupValue(fields...))
+	}
+
+	return append(buf, '}')
+}
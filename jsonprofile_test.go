@@ -0,0 +1,105 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// duplicateJSONKey returns the first key which appears twice in the same object of "data".
+func duplicateJSONKey(t *testing.T, data []byte) string {
+	t.Helper()
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func() string
+	walk = func() string {
+		token, err := dec.Token()
+		if err != nil {
+			t.Fatalf("decode: %v: %s", err, data)
+		}
+
+		switch token {
+		case json.Delim('{'):
+			keys := make(map[string]bool)
+			for dec.More() {
+				key, _ := dec.Token()
+				if keys[key.(string)] {
+					return key.(string)
+				}
+				keys[key.(string)] = true
+
+				if dup := walk(); dup != "" {
+					return dup
+				}
+			}
+			dec.Token() // }
+		case json.Delim('['):
+			for dec.More() {
+				if dup := walk(); dup != "" {
+					return dup
+				}
+			}
+			dec.Token() // ]
+		}
+
+		return ""
+	}
+
+	return walk()
+}
+
+func TestJSONProfileDuplicateKeys(t *testing.T) {
+	profiles := map[string]JSONProfile{
+		"gcp":   JSONProfileGCP,
+		"aws":   JSONProfileAWS,
+		"azure": JSONProfileAzure,
+	}
+
+	for name, profile := range profiles {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New().SetOutput(&buf).SetPrefix("http").SetReportCaller(true).SetStacktraceLevel("error")
+			logger.SetFormat("json", profile)
+
+			logger.Errorw("failed",
+				"trace_id", "t1", "traceId", "t2", "span_id", "s1", "spanId", "s2",
+				"caller", "user", "stacktrace", "user", "stack_trace", "user", "stackTrace", "user",
+				"severity", "user", "level", "user", "message", "user", "time", "user", "timestamp", "user",
+				"logger", "user", "location", "user", "category", "user", "fields", "user",
+				"logging.googleapis.com/trace", "user")
+
+			if dup := duplicateJSONKey(t, buf.Bytes()); dup != "" {
+				t.Fatalf("duplicate key %q: %s", dup, buf.Bytes())
+			}
+		})
+	}
+}
+
+func TestJSONProfileTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New().SetOutput(&buf)
+	logger.SetFormat("json", JSONProfileAzure)
+	logger.Infow("request", "traceId", "t1", "trace_id", "t2", "span_id", "s1", "caller", "user")
+
+	var got struct {
+		OperationID       string         `json:"operation_Id"`
+		OperationParentID string         `json:"operation_ParentId"`
+		Properties        map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v: %s", err, buf.Bytes())
+	}
+
+	if got.OperationID != "t1" || got.OperationParentID != "s1" {
+		t.Fatalf("expected the first trace and span fields but got %q and %q", got.OperationID, got.OperationParentID)
+	}
+
+	if got.Properties["trace_id"] != "t2" {
+		t.Fatalf("expected the second trace field to be kept as a property: %s", buf.Bytes())
+	}
+
+	if fields, _ := got.Properties["fields"].(map[string]any); fields["caller"] != "user" {
+		t.Fatalf("expected the caller field under the fields property: %s", buf.Bytes())
+	}
+}
@@ -59,31 +59,36 @@ var Levels = map[Level]*LevelMetadata{
 		Title:            "",
 	},
 	FatalLevel: {
-		Name:      "fatal",
-		Title:     "[FTAL]",
-		ColorCode: printer.Red,
-		Style:     []printer.RichOption{printer.Background},
+		Name:           "fatal",
+		Title:          "[FTAL]",
+		ColorCode:      printer.Red,
+		Style:          []printer.RichOption{printer.Background},
+		SeverityNumber: 21,
 	},
 	ErrorLevel: {
-		Name:      "error",
-		Title:     "[ERRO]",
-		ColorCode: printer.Red,
+		Name:           "error",
+		Title:          "[ERRO]",
+		ColorCode:      printer.Red,
+		SeverityNumber: 17,
 	},
 	WarnLevel: {
 		Name:             "warn",
 		AlternativeNames: []string{"warning"},
 		Title:            "[WARN]",
 		ColorCode:        printer.Magenta,
+		SeverityNumber:   13,
 	},
 	InfoLevel: {
-		Name:      "info",
-		Title:     "[INFO]",
-		ColorCode: printer.Cyan,
+		Name:           "info",
+		Title:          "[INFO]",
+		ColorCode:      printer.Cyan,
+		SeverityNumber: 9,
 	},
 	DebugLevel: {
-		Name:      "debug",
-		Title:     "[DBUG]",
-		ColorCode: printer.Yellow,
+		Name:           "debug",
+		Title:          "[DBUG]",
+		ColorCode:      printer.Yellow,
+		SeverityNumber: 5,
 	},
 }
 
@@ -129,6 +134,10 @@ type LevelMetadata struct {
 	ColorCode int
 	// Style one or more rich options for the `Title`.
 	Style []printer.RichOption
+	// SeverityNumber is the OpenTelemetry severity number of the level,
+	// from 1 (TRACE) to 24 (FATAL4), see the "otel" formatter.
+	// Custom levels should declare theirs, zero means unspecified.
+	SeverityNumber int
 }
 
 // Text returns the text that should be
@@ -218,3 +227,25 @@ var (
 		return ""
 	}
 )
+
+// syslogSeverity returns the syslog severity (RFC 5424) of the level,
+// based on its `SeverityNumber`.
+func (l Level) syslogSeverity() int {
+	meta, ok := Levels[l]
+	if !ok {
+		return 6 // informational.
+	}
+
+	switch n := meta.SeverityNumber; {
+	case n >= 21: // fatal.
+		return 2 // critical.
+	case n >= 17: // error.
+		return 3 // error.
+	case n >= 13: // warn.
+		return 4 // warning.
+	case n >= 9 || n == 0: // info or unspecified.
+		return 6 // informational.
+	default: // debug and trace.
+		return 7 // debug.
+	}
+}
@@ -1,10 +1,13 @@
 package golog
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,8 +24,14 @@ type Log struct {
 	// Message is the string reprensetation of the log's main body.
 	Message string `json:"message"`
 	// Fields any data information useful to represent this log.
-	Fields Fields `json:"fields,omitempty"`
-	// Stacktrace contains the stack callers when on `Debug` level.
+	// The fields are kept in the order they were added,
+	// see `Logger.SortFields` too.
+	Fields FieldList `json:"fields,omitempty"`
+	// Caller is the location of the log's caller,
+	// it's recorded on all levels when `Logger.ReportCaller` is true.
+	Caller Frame `json:"caller,omitzero"`
+	// Stacktrace contains the stack callers when on `Debug` level,
+	// see `Logger.StacktraceLevel` and `Logger.ErrorStacktrace` too.
 	// The first one should be the Logger's direct caller function.
 	Stacktrace []Frame `json:"stacktrace,omitempty"`
 	// NewLine returns false if this Log
@@ -33,6 +42,10 @@ type Log struct {
 	// NewLine has to do with the methods called,
 	// not the original content of the `Message`.
 	NewLine bool `json:"-"`
+	// Context is the context passed to the `LogContext`, `InfoContext`, `ErrorContext`...
+	// methods, it is nil when the log was printed without a context.
+	// Custom handlers can use it to pass it on, e.g. to a `slog.Handler`.
+	Context context.Context `json:"-"`
 }
 
 // Frame represents the log's caller.
@@ -46,6 +59,10 @@ type Frame struct {
 	// location in this frame. For non-leaf frames, this will be
 	// the location of a call.
 	Source string `json:"source"`
+	// File is the file name of the location in this frame.
+	File string `json:"-"`
+	// Line is the line number of the location in this frame.
+	Line int `json:"-"`
 }
 
 // String method returns the concat value of "file:line".
@@ -54,6 +71,21 @@ func (f Frame) String() string {
 	return f.Source
 }
 
+// ShortSource returns the base file name and line number,
+// e.g. "main.go:29", of the location in this frame.
+func (f Frame) ShortSource() string {
+	if f.File == "" {
+		return f.Source
+	}
+
+	return fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line)
+}
+
+// IsZero reports whether the frame is empty.
+func (f Frame) IsZero() bool {
+	return f.Source == "" && f.Function == ""
+}
+
 // FormatTime returns the formatted `Time`.
 func (l *Log) FormatTime() string {
 	if l.Logger.TimeFormat == "" {
@@ -62,17 +94,36 @@ func (l *Log) FormatTime() string {
 	return l.Time.Format(l.Logger.TimeFormat)
 }
 
+// Prefix returns the Logger's prefix, without the trailing ": "
+// of the child loggers, e.g. "http" or "app: http".
+func (l *Log) Prefix() string {
+	return strings.TrimSuffix(strings.TrimSpace(l.Logger.Prefix), ":")
+}
+
+// Field returns the value of the top-level field of the given "key",
+// like the map lookup of the previous `Fields` type of `Log.Fields`.
+// Nested groups are returned as `Fields`. See `FieldList.Get` too.
+func (l *Log) Field(key string) (any, bool) {
+	return l.Fields.Get(key)
+}
+
 var funcNameReplacer = strings.NewReplacer(")", "", "(", "", "*", "")
 
 // GetStacktrace tries to return the callers of this function.
-func GetStacktrace(limit int) (callerFrames []Frame) {
+func GetStacktrace(limit int) []Frame {
 	if limit < 0 {
 		return nil
 	}
 
 	var pcs [32]uintptr
 	n := runtime.Callers(1, pcs[:])
-	frames := runtime.CallersFrames(pcs[:n])
+	return callersFrames(pcs[:n], limit)
+}
+
+// callersFrames returns the frames of the "pcs" program counters,
+// the golog's and the standard library's frames are excluded.
+func callersFrames(pcs []uintptr, limit int) (callerFrames []Frame) {
+	frames := runtime.CallersFrames(pcs)
 
 	for {
 		f, more := frames.Next()
@@ -90,21 +141,7 @@ func GetStacktrace(limit int) (callerFrames []Frame) {
 		}
 
 		if file != "" { // keep it here, break should be respected.
-			funcName := f.Function
-			if idx := strings.Index(funcName, ".("); idx > 1 {
-				funcName = funcNameReplacer.Replace(funcName[idx+1:])
-				// e.g. method: github.com/kataras/iris/v12.(*Application).Listen to:
-				//      Application.Listen
-			} else if idx = strings.LastIndexByte(funcName, '/'); idx >= 0 && len(funcName) > idx+1 {
-				funcName = strings.Replace(funcName[idx+1:], ".", "/", 1)
-				// e.g. package-level function: github.com/kataras/iris/v12/context.Do to
-				// context/Do
-			}
-
-			callerFrames = append(callerFrames, Frame{
-				Function: funcName,
-				Source:   fmt.Sprintf("%s:%d", f.File, f.Line),
-			})
+			callerFrames = append(callerFrames, newFrame(f))
 
 			if limit > 0 && len(callerFrames) >= limit {
 				break
@@ -118,3 +155,187 @@ func GetStacktrace(limit int) (callerFrames []Frame) {
 
 	return
 }
+
+// StackTracer is implemented by errors which carry
+// the stack of the location they were created at.
+// The `github.com/pkg/errors`-style `StackTrace()` methods,
+// which return a slice of program counters of a named type, are supported too.
+//
+// See `Logger.SetErrorStacktrace`.
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// GetErrorStacktrace returns the stack carried by the "err" error
+// or by the deepest error of its cause tree which carries one.
+// It returns nil if no error of the tree carries a stack.
+func GetErrorStacktrace(err error, limit int) []Frame {
+	if limit < 0 {
+		return nil
+	}
+
+	pcs := errorStack(err, 0)
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	return callersFrames(pcs, limit)
+}
+
+// errorStack returns the program counters of the deepest error which carries a stack.
+func errorStack(err error, depth int) []uintptr {
+	if err == nil || depth >= maxErrorDepth {
+		return nil
+	}
+
+	var causes []error
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		causes = e.Unwrap()
+	case interface{ Unwrap() error }:
+		causes = []error{e.Unwrap()}
+	}
+
+	for _, cause := range causes {
+		if pcs := errorStack(cause, depth+1); len(pcs) > 0 {
+			return pcs
+		}
+	}
+
+	return stackOf(err)
+}
+
+// stackOf returns the program counters of the "err" error's own stack.
+func stackOf(err error) []uintptr {
+	if st, ok := err.(StackTracer); ok {
+		return st.StackTrace()
+	}
+
+	// e.g. github.com/pkg/errors: StackTrace() errors.StackTrace, a []Frame of uintptr.
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	stack := method.Call(nil)[0]
+	pcs := make([]uintptr, stack.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(stack.Index(i).Uint())
+	}
+
+	return pcs
+}
+
+// newFrame returns a Frame of the runtime frame "f".
+func newFrame(f runtime.Frame) Frame {
+	funcName := f.Function
+	if idx := strings.Index(funcName, ".("); idx > 1 {
+		funcName = funcNameReplacer.Replace(funcName[idx+1:])
+		// e.g. method: github.com/kataras/iris/v12.(*Application).Listen to:
+		//      Application.Listen
+	} else if idx = strings.LastIndexByte(funcName, '/'); idx >= 0 && len(funcName) > idx+1 {
+		funcName = strings.Replace(funcName[idx+1:], ".", "/", 1)
+		// e.g. package-level function: github.com/kataras/iris/v12/context.Do to
+		// context/Do
+	}
+
+	return Frame{
+		Function: funcName,
+		Source:   fmt.Sprintf("%s:%d", f.File, f.Line),
+		File:     f.File,
+		Line:     f.Line,
+	}
+}
+
+// helpers holds the function names marked by `Helper`.
+var helpers sync.Map
+
+// Helper marks the calling function as a logging helper function.
+// When reporting the caller, see `Logger.SetReportCaller`,
+// the helper functions are skipped, like the `testing.T.Helper` does.
+//
+// Usage:
+//
+//	func logRequest(r *http.Request) {
+//		golog.Helper()
+//		golog.Infow("request", "path", r.URL.Path) // reports the logRequest's caller.
+//	}
+func Helper() {
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	if _, ok := helpers.Load(frame.Function); !ok {
+		helpers.Store(frame.Function, struct{}{})
+	}
+}
+
+const (
+	gologPackagePrefix = "github.com/kataras/golog."
+	slogPackagePrefix  = "log/slog."
+)
+
+// GetCaller returns the first caller outside of the golog and slog packages,
+// skipping the functions marked by `Helper` and "skip" more frames.
+func GetCaller(skip int) (Frame, bool) {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		f, more := frames.Next()
+		switch {
+		case strings.HasPrefix(f.Function, gologPackagePrefix),
+			strings.HasPrefix(f.Function, slogPackagePrefix):
+		case isHelper(f.Function):
+		case skip > 0:
+			skip--
+		default:
+			if f.File != "" {
+				return newFrame(f), true
+			}
+		}
+
+		if !more {
+			return Frame{}, false
+		}
+	}
+}
+
+// callerFrame returns the frame of the "pc" program counter, e.g. of a `slog.Record`.
+func callerFrame(pc uintptr) (Frame, bool) {
+	f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if f.File == "" {
+		return Frame{}, false
+	}
+
+	return newFrame(f), true
+}
+
+func isHelper(funcName string) bool {
+	_, ok := helpers.Load(funcName)
+	return ok
+}
+
+// stacktraceString returns the "frames" in the format of a Go panic's stack,
+// the function and its source indented on the next line, per frame.
+func stacktraceString(frames []Frame) string {
+	var b strings.Builder
+	for i, frame := range frames {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.Source)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,181 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LogfmtFormatter is a Formatter type for logfmt logs, e.g.
+//
+//	time="2006/01/02 15:04" level=info prefix=http msg="request handled" http.method=GET status=200
+//
+// The time is formatted by the Logger's `TimeFormat` and it's omitted when that is empty.
+// Nested fields are written with their keys separated by a dot.
+type LogfmtFormatter struct{}
+
+// String returns the name of the Formatter.
+// In this case it returns "logfmt".
+// It's used to map the formatter names with their implementations.
+func (f *LogfmtFormatter) String() string {
+	return "logfmt"
+}
+
+// Options returns a new logfmt Formatter, it accepts no options.
+func (f *LogfmtFormatter) Options(opts ...any) Formatter {
+	return new(LogfmtFormatter)
+}
+
+// Format prints the logs in logfmt format.
+//
+// Usage:
+// logger.SetFormat("logfmt") or
+// logger.SetLevelFormat("info", "logfmt")
+func (f *LogfmtFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	buf := *bufPtr
+	if t := log.FormatTime(); t != "" {
+		buf = appendLogfmtPair(buf, "time", t)
+	}
+
+	if log.Level != DisableLevel {
+		buf = appendLogfmtPair(buf, "level", log.Level.String())
+	}
+
+	if !log.Caller.IsZero() {
+		buf = appendLogfmtPair(buf, "caller", log.Caller.Source)
+	}
+
+	if prefix := log.Prefix(); prefix != "" {
+		buf = appendLogfmtPair(buf, "prefix", prefix)
+	}
+
+	buf = appendLogfmtPair(buf, "msg", log.Message)
+	buf = appendLogfmtFields(buf, "", log.Fields)
+
+	if len(log.Stacktrace) > 0 {
+		frames := make([]string, 0, len(log.Stacktrace))
+		for _, frame := range log.Stacktrace {
+			frames = append(frames, frame.Function+"@"+frame.Source)
+		}
+		buf = appendLogfmtPair(buf, "stacktrace", strings.Join(frames, " "))
+	}
+
+	buf = append(buf, '\n')
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+func appendLogfmtFields(buf []byte, prefix string, fields FieldList) []byte {
+	for _, f := range fields {
+		if f.Value.Kind() == slog.KindGroup {
+			buf = appendLogfmtFields(buf, prefix+f.Key+".", f.Value.Group())
+			continue
+		}
+
+		buf = appendLogfmtKey(buf, prefix+f.Key)
+		buf = appendLogfmtValue(buf, f.Value)
+	}
+
+	return buf
+}
+
+func appendLogfmtPair(buf []byte, key, value string) []byte {
+	buf = appendLogfmtKey(buf, key)
+	return appendLogfmtString(buf, value)
+}
+
+// appendLogfmtKey appends the " key=" (the space is omitted for the first pair),
+// the characters which are not allowed in a key are replaced by an underscore.
+func appendLogfmtKey(buf []byte, key string) []byte {
+	if len(buf) > 0 {
+		buf = append(buf, ' ')
+	}
+
+	if key == "" {
+		key = "_"
+	}
+
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			buf = append(buf, '_')
+			continue
+		}
+		buf = utf8.AppendRune(buf, r)
+	}
+
+	return append(buf, '=')
+}
+
+func appendLogfmtValue(buf []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return appendLogfmtString(buf, v.String())
+	case slog.KindInt64:
+		return strconv.AppendInt(buf, v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.AppendUint(buf, v.Uint64(), 10)
+	case slog.KindFloat64:
+		return strconv.AppendFloat(buf, v.Float64(), 'g', -1, 64)
+	case slog.KindBool:
+		return strconv.AppendBool(buf, v.Bool())
+	case slog.KindDuration:
+		return appendLogfmtString(buf, v.Duration().String())
+	case slog.KindTime:
+		return v.Time().AppendFormat(buf, time.RFC3339Nano)
+	default:
+		return appendLogfmtString(buf, fmt.Sprint(v.Any()))
+	}
+}
+
+// appendLogfmtString appends the "s", quoted when it's empty
+// or it contains spaces, equal signs, quotes or control characters.
+func appendLogfmtString(buf []byte, s string) []byte {
+	if !logfmtNeedsQuote(s) {
+		return append(buf, s...)
+	}
+
+	buf = append(buf, '"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			buf = append(buf, '\\', byte(r))
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		default:
+			if r < ' ' || r == utf8.RuneError {
+				buf = fmt.Appendf(buf, `\u%04x`, r)
+				continue
+			}
+			buf = utf8.AppendRune(buf, r)
+		}
+	}
+
+	return append(buf, '"')
+}
+
+func logfmtNeedsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,6 +2,7 @@ package golog
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kataras/golog/printer"
 )
@@ -34,6 +36,27 @@ type Logger struct {
 	TimeFormat string
 	// Limit stacktrace entries on `Debug` level.
 	StacktraceLimit int
+	// StacktraceLevel is the least severe level which records the stacktrace,
+	// e.g. `ErrorLevel` records it on fatal and error logs only.
+	// When it's `DisableLevel` (the default) the stacktrace is recorded on `Debug` level only.
+	StacktraceLevel Level
+	// ErrorStacktrace reports whether the stack carried by an error field,
+	// see `StackTracer`, should be recorded instead of the log's call site one.
+	ErrorStacktrace bool
+	// ReportCaller reports whether the location of the log's caller
+	// should be recorded to the `Log.Caller` on all levels.
+	// It defaults to false.
+	ReportCaller bool
+	// CallerSkip is the number of additional stack frames to skip
+	// when reporting the caller, see `Helper` too.
+	CallerSkip int
+	// CallerFullPath reports whether the text output should print
+	// the full path of the caller's file instead of its base name.
+	CallerFullPath bool
+	// SortFields reports whether the fields should be sorted by key,
+	// otherwise they keep the order they were added.
+	// It defaults to false.
+	SortFields bool
 	// if new line should be added on all log functions, even the `F`s.
 	// It defaults to true.
 	//
@@ -55,6 +78,11 @@ type Logger struct {
 	handlers []Handler
 	logs     sync.Pool
 	children *loggerMap
+	root     *Logger   // the Logger which holds the configuration of a derived one, see `With`.
+	fields   FieldList // attached to every log, see `With`.
+	groups   []string  // the groups the next fields are nested under, see `WithGroup`.
+
+	contextExtractors []ContextExtractor
 }
 
 // New returns a new golog with a default output to `os.Stdout`
@@ -67,7 +95,20 @@ func New() *Logger {
 		Printer:     printer.NewPrinter(os.Stdout),
 		LevelOutput: make(map[Level]io.Writer),
 		formatters: map[string]Formatter{ // the available builtin formatters.
-			"json": new(JSONFormatter),
+			"cbor":     new(CBORFormatter),
+			"csv":      new(CSVFormatter),
+			"ecs":      new(ECSFormatter),
+			"gelf":     new(GELFFormatter),
+			"journald": new(JournaldFormatter),
+			"html":     new(HTMLFormatter),
+			"json":     new(JSONFormatter),
+			"logfmt":   new(LogfmtFormatter),
+			"msgpack":  new(MsgPackFormatter),
+			"otel":     new(OTelFormatter),
+			"pretty":   new(PrettyFormatter),
+			"syslog":   new(SyslogFormatter),
+			"text":     new(TextFormatter),
+			"tsv":      &CSVFormatter{Comma: '\t'},
 		},
 		LevelFormatter: make(map[Level]Formatter),
 		children:       newLoggerMap(),
@@ -78,10 +119,27 @@ func New() *Logger {
 // One or more values of `Fields` type can be passed
 // on all Log methods except `Print/Printf/Println` to set the `Log.Fields` field,
 // which can be accessed through a custom LogHandler.
+//
+// A `Fields` value can hold other `Fields` values to represent nested groups,
+// e.g. Fields{"http": Fields{"method": "GET"}}, text output prints them
+// with dotted keys (http.method=GET) and JSON output as nested objects.
+//
+// As a map has no order, its keys are added to the `Log.Fields` sorted,
+// use `slog.Attr` values or the `With` method's key/value pairs to keep the call-site order.
 type Fields map[string]any
 
+// base returns the Logger which holds the configuration of "l",
+// the parent of a derived Logger or "l" itself.
+func (l *Logger) base() *Logger {
+	if l.root != nil {
+		return l.root
+	}
+
+	return l
+}
+
 // acquireLog returns a new log fom the pool.
-func (l *Logger) acquireLog(level Level, msg string, withPrintln bool, fields Fields) *Log {
+func (l *Logger) acquireLog(ctx context.Context, level Level, msg string, withPrintln bool, fields FieldList) *Log {
 	log, ok := l.logs.Get().(*Log)
 	if !ok {
 		log = &Log{
@@ -90,24 +148,25 @@ func (l *Logger) acquireLog(level Level, msg string, withPrintln bool, fields Fi
 	}
 
 	log.NewLine = withPrintln
-	if l.TimeFormat != "" {
-		log.Time = Now()
-		log.Timestamp = log.Time.Unix()
-	}
+	// The time is always recorded, the formatters which print it
+	// by the Logger's TimeFormat omit it when that is empty.
+	log.Time = Now()
+	log.Timestamp = log.Time.Unix()
 	log.Level = level
 	log.Message = msg
 	log.Fields = fields
 	log.Stacktrace = log.Stacktrace[:0]
+	log.Caller = Frame{}
+	log.Context = ctx
 	return log
 }
 
 // releaseLog Log releases a log instance back to the pool.
 func (l *Logger) releaseLog(log *Log) {
+	log.Context = nil // do not keep the context alive.
 	l.logs.Put(log)
 }
 
-var spaceBytes = []byte(" ")
-
 // formatLog formats and writes the log entry directly to the output writer.
 func (l *Logger) formatLog(log *Log) {
 	l.mu.Lock()
@@ -116,40 +175,18 @@ func (l *Logger) formatLog(log *Log) {
 	w := l.getOutput(log.Level)
 
 	// Check if a custom formatter should handle this
-	if f := l.getFormatter(); f != nil {
+	if f := l.getFormatter(log.Level); f != nil {
 		if f.Format(w, log) {
 			return
 		}
 	}
 
-	// Format the log entry directly
-	if log.Level != DisableLevel {
-		if level, ok := Levels[log.Level]; ok {
-			printer.WriteRich(w, level.Title, level.ColorCode, level.Style...)
-			_, _ = w.Write(spaceBytes)
-		}
-	}
-
-	if t := log.FormatTime(); t != "" {
-		_, _ = fmt.Fprint(w, t)
-		_, _ = w.Write(spaceBytes)
-	}
-
-	if prefix := l.Prefix; len(prefix) > 0 {
-		_, _ = fmt.Fprint(w, prefix)
-	}
-
-	_, _ = fmt.Fprint(w, log.Message)
-
-	for k, v := range log.Fields {
-		_, _ = fmt.Fprintf(w, " %s=%v", k, v)
-	}
-
-	if l.NewLine {
-		_, _ = fmt.Fprintln(w)
-	}
+	// Format the log entry through the default text layout.
+	defaultTextFormatter.Format(w, log)
 }
 
+var defaultTextFormatter = &TextFormatter{Layout: DefaultTextLayout, layout: defaultTextLayout}
+
 // NopOutput disables the output.
 var NopOutput = printer.NopOutput()
 
@@ -157,7 +194,9 @@ var NopOutput = printer.NopOutput()
 //
 // Returns itself.
 func (l *Logger) SetOutput(w io.Writer) *Logger {
-	l.Printer.SetOutput(w)
+	c := l.base()
+	c.Printer.SetOutput(w)
+	c.keepFormatterOutputs()
 	return l
 }
 
@@ -168,10 +207,36 @@ func (l *Logger) SetOutput(w io.Writer) *Logger {
 //
 // Returns itself.
 func (l *Logger) AddOutput(writers ...io.Writer) *Logger {
-	l.Printer.AddOutput(writers...)
+	c := l.base()
+	c.Printer.AddOutput(writers...)
+	c.keepFormatterOutputs()
 	return l
 }
 
+// keepFormatterOutputs lets the formatters which keep a state per output,
+// e.g. the CSV one, forget the outputs which are not used anymore.
+func (l *Logger) keepFormatterOutputs() {
+	outputs := l.Printer.Writers()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, w := range l.LevelOutput {
+		outputs = append(outputs, w)
+	}
+
+	keepOutputs := func(f Formatter) {
+		if f, ok := f.(interface{ keepOutputs([]io.Writer) }); ok {
+			f.keepOutputs(outputs)
+		}
+	}
+
+	keepOutputs(l.formatter)
+	for _, f := range l.LevelFormatter {
+		keepOutputs(f)
+	}
+}
+
 // SetPrefix sets a prefix for this "l" Logger.
 //
 // The prefix is the text that is being presented
@@ -179,9 +244,10 @@ func (l *Logger) AddOutput(writers ...io.Writer) *Logger {
 //
 // Returns itself.
 func (l *Logger) SetPrefix(s string) *Logger {
-	l.mu.Lock()
-	l.Prefix = s
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.Prefix = s
+	c.mu.Unlock()
 	return l
 }
 
@@ -190,9 +256,10 @@ func (l *Logger) SetPrefix(s string) *Logger {
 //
 // Returns itself.
 func (l *Logger) SetTimeFormat(s string) *Logger {
-	l.mu.Lock()
-	l.TimeFormat = s
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.TimeFormat = s
+	c.mu.Unlock()
 
 	return l
 }
@@ -202,43 +269,156 @@ func (l *Logger) SetTimeFormat(s string) *Logger {
 // Zero means all number of stack entries will be logged.
 // Negative value disables the stacktrace field.
 func (l *Logger) SetStacktraceLimit(limit int) *Logger {
-	l.mu.Lock()
-	l.StacktraceLimit = limit
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.StacktraceLimit = limit
+	c.mu.Unlock()
+
+	return l
+}
+
+// SetReportCaller sets whether the location of the log's caller
+// should be recorded to the `Log.Caller` on all levels.
+// The text output prints it right after the time
+// and the JSON formatter under the "caller" key.
+//
+// See `SetCallerSkip`, `SetCallerFullPath` and `Helper` too.
+//
+// Returns itself.
+func (l *Logger) SetReportCaller(report bool) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.ReportCaller = report
+	c.mu.Unlock()
+
+	return l
+}
+
+// SetCallerSkip sets the number of additional stack frames
+// to skip when reporting the caller.
+//
+// Returns itself.
+func (l *Logger) SetCallerSkip(skip int) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.CallerSkip = skip
+	c.mu.Unlock()
+
+	return l
+}
+
+// SetCallerFullPath sets whether the text output should print
+// the full path of the caller's file instead of its base name.
+//
+// Returns itself.
+func (l *Logger) SetCallerFullPath(fullPath bool) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.CallerFullPath = fullPath
+	c.mu.Unlock()
+
+	return l
+}
+
+// SetSortFields sets whether the fields should be sorted by key
+// on text, JSON and handlers output. By default they keep the order they were added.
+//
+// Returns itself.
+func (l *Logger) SetSortFields(sort bool) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.SortFields = sort
+	c.mu.Unlock()
+
+	return l
+}
+
+// SetStacktraceLevel sets the least severe level, by its name,
+// which records the stacktrace. E.g. "error" records it
+// on fatal and error logs only, while debug logs don't pay for it.
+// By default the stacktrace is recorded on `Debug` level only.
+//
+// Returns itself.
+func (l *Logger) SetStacktraceLevel(levelName string) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.StacktraceLevel = ParseLevel(levelName)
+	c.mu.Unlock()
 
 	return l
 }
 
+// SetErrorStacktrace sets whether the stack carried by an error field,
+// see `StackTracer`, should be recorded instead of the log's call site one,
+// when one is present.
+//
+// Returns itself.
+func (l *Logger) SetErrorStacktrace(fromError bool) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.ErrorStacktrace = fromError
+	c.mu.Unlock()
+
+	return l
+}
+
+func (l *Logger) recordsStacktrace(level Level) bool {
+	if l.StacktraceLevel == DisableLevel {
+		return level == DebugLevel
+	}
+
+	return level != DisableLevel && level <= l.StacktraceLevel
+}
+
+func (l *Logger) getStacktrace(log *Log) []Frame {
+	if l.ErrorStacktrace {
+		for _, f := range log.Fields {
+			if info, ok := f.Value.Any().(*ErrorInfo); ok {
+				if stacktrace := GetErrorStacktrace(info.Err, l.StacktraceLimit); len(stacktrace) > 0 {
+					return stacktrace
+				}
+			}
+		}
+	}
+
+	return GetStacktrace(l.StacktraceLimit)
+}
+
 // DisableNewLine disables the new line suffix on every log function, even the `F`'s,
 // the caller should add "\n" to the log message manually after this call.
 //
 // Returns itself.
 func (l *Logger) DisableNewLine() *Logger {
-	l.mu.Lock()
-	l.NewLine = false
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.NewLine = false
+	c.mu.Unlock()
 
 	return l
 }
 
 // RegisterFormatter registers a Formatter for this logger.
 func (l *Logger) RegisterFormatter(f Formatter) *Logger {
-	l.mu.Lock()
-	l.formatters[f.String()] = f
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.formatters[f.String()] = f
+	c.mu.Unlock()
 	return l
 }
 
 // SetFormat sets a formatter for all logger's logs.
 func (l *Logger) SetFormat(formatter string, opts ...any) *Logger {
-	l.mu.RLock()
-	f, ok := l.formatters[formatter]
-	l.mu.RUnlock()
+	c := l.base()
+	c.mu.RLock()
+	f, ok := c.formatters[formatter]
+	c.mu.RUnlock()
 
 	if ok {
-		l.mu.Lock()
-		l.formatter = f.Options(opts...)
-		l.mu.Unlock()
+		f = f.Options(opts...)
+		c.mu.Lock()
+		c.formatter = f
+		c.mu.Unlock()
+		c.reportFormatterError(f)
 	}
 
 	return l
@@ -246,21 +426,34 @@ func (l *Logger) SetFormat(formatter string, opts ...any) *Logger {
 
 // SetLevelFormat changes the output format for the given "levelName".
 func (l *Logger) SetLevelFormat(levelName string, formatter string, opts ...any) *Logger {
-	l.mu.RLock()
-	f, ok := l.formatters[formatter]
-	l.mu.RUnlock()
+	c := l.base()
+	c.mu.RLock()
+	f, ok := c.formatters[formatter]
+	c.mu.RUnlock()
 
 	if ok {
-		l.mu.Lock()
-		l.LevelFormatter[ParseLevel(levelName)] = f.Options(opts...)
-		l.mu.Unlock()
+		f = f.Options(opts...)
+		c.mu.Lock()
+		c.LevelFormatter[ParseLevel(levelName)] = f
+		c.mu.Unlock()
+		c.reportFormatterError(f)
 	}
 
 	return l
 }
 
-func (l *Logger) getFormatter() Formatter {
-	f, ok := l.LevelFormatter[l.Level]
+// reportFormatterError logs the error of invalid formatter options,
+// e.g. a text layout, which the formatter replaced by its defaults.
+func (l *Logger) reportFormatterError(f Formatter) {
+	if e, ok := f.(interface{ Err() error }); ok {
+		if err := e.Err(); err != nil {
+			l.Error(err)
+		}
+	}
+}
+
+func (l *Logger) getFormatter(level Level) Formatter {
+	f, ok := l.LevelFormatter[level]
 	if !ok {
 		f = l.formatter
 	}
@@ -275,9 +468,11 @@ func (l *Logger) getFormatter() Formatter {
 // SetLevelOutput sets a destination log output for the specific "levelName".
 // For multiple writers use the `io.Multiwriter` wrapper.
 func (l *Logger) SetLevelOutput(levelName string, w io.Writer) *Logger {
-	l.mu.Lock()
-	l.LevelOutput[ParseLevel(levelName)] = w
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.LevelOutput[ParseLevel(levelName)] = w
+	c.mu.Unlock()
+	c.keepFormatterOutputs()
 	return l
 }
 
@@ -285,9 +480,10 @@ func (l *Logger) SetLevelOutput(levelName string, w io.Writer) *Logger {
 // If not a registered writer is set for that level then it returns
 // the logger's default printer. It does NOT return nil.
 func (l *Logger) GetLevelOutput(levelName string) io.Writer {
-	l.mu.RLock()
-	w := l.getOutput(ParseLevel(levelName))
-	l.mu.RUnlock()
+	c := l.base()
+	c.mu.RLock()
+	w := c.getOutput(ParseLevel(levelName))
+	c.mu.RUnlock()
 	return w
 }
 
@@ -314,29 +510,80 @@ func (l *Logger) getOutput(level Level) io.Writer {
 //
 // Returns itself.
 func (l *Logger) SetLevel(levelName string) *Logger {
-	l.mu.Lock()
-	l.Level = ParseLevel(levelName)
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.Level = ParseLevel(levelName)
+	c.mu.Unlock()
+
+	return l
+}
 
+// AddContextExtractor registers a function which pulls fields,
+// e.g. request, user or trace IDs, out of the context
+// passed to the `LogContext`, `InfoContext`, `ErrorContext`... methods.
+// The extracted fields are merged into the `Log.Fields`,
+// the fields passed on the call override them.
+//
+// Returns itself.
+func (l *Logger) AddContextExtractor(extractor ContextExtractor) *Logger {
+	c := l.base()
+	c.mu.Lock()
+	c.contextExtractors = append(c.contextExtractors, extractor)
+	c.mu.Unlock()
 	return l
 }
 
-func (l *Logger) print(level Level, msg string, newLine bool, fields Fields) {
-	if l.Level >= level {
+func (l *Logger) extractFields(ctx context.Context) FieldList {
+	extractors := l.base().contextExtractors
+	if ctx == nil || len(extractors) == 0 {
+		return l.fields
+	}
+
+	fields := l.fields
+	for _, extractor := range extractors {
+		fields = mergeFields(fields, extractor(ctx).fieldList())
+	}
+
+	return fields
+}
+
+func (l *Logger) print(ctx context.Context, level Level, msg string, newLine bool, fields FieldList) {
+	l.printAt(ctx, level, msg, newLine, fields, time.Time{}, 0)
+}
+
+// printAt prints a log of the given time and caller's program counter, e.g. of a slog record,
+// the zero ones are replaced by the current time and the location of the log's caller.
+func (l *Logger) printAt(ctx context.Context, level Level, msg string, newLine bool, fields FieldList, t time.Time, pc uintptr) {
+	c := l.base() // the configuration of a derived logger is its parent's one.
+	if c.Level >= level {
+		fields = resolveFields(mergeFields(l.extractFields(ctx), groupFields(l.groups, fields)))
+		if c.SortFields {
+			fields = fields.Sorted()
+		}
 		// newLine passed here in order for handler to know
 		// if this message derives from Println and Leveled functions
 		// or by simply, Print.
-		log := l.acquireLog(level, msg, newLine, fields)
-		if level == DebugLevel {
-			log.Stacktrace = GetStacktrace(l.StacktraceLimit)
+		log := c.acquireLog(ctx, level, msg, newLine, fields)
+		if !t.IsZero() {
+			log.Time, log.Timestamp = t, t.Unix()
+		}
+		if c.ReportCaller {
+			if pc != 0 {
+				log.Caller, _ = callerFrame(pc)
+			} else {
+				log.Caller, _ = GetCaller(c.CallerSkip)
+			}
+		}
+		if c.recordsStacktrace(level) {
+			log.Stacktrace = c.getStacktrace(log)
 		}
 		// if not handled by one of the handler
 		// then format and print it as usual.
-		if !l.handled(log) {
-			l.formatLog(log)
+		if !c.handled(log) {
+			c.formatLog(log)
 		}
 
-		l.releaseLog(log)
+		c.releaseLog(log)
 	}
 	// if level was fatal we don't care about the logger's level, we'll exit.
 	if level == FatalLevel {
@@ -346,59 +593,65 @@ func (l *Logger) print(level Level, msg string, newLine bool, fields Fields) {
 
 // Print prints a log message without levels and colors.
 func (l *Logger) Print(v ...any) {
-	l.print(DisableLevel, fmt.Sprint(v...), l.NewLine, nil)
+	l.print(nil, DisableLevel, fmt.Sprint(v...), l.base().NewLine, nil)
 }
 
 // Printf formats according to a format specifier and writes to `Printer#Output` without levels and colors.
 func (l *Logger) Printf(format string, args ...any) {
-	l.print(DisableLevel, fmt.Sprintf(format, args...), l.NewLine, nil)
+	l.print(nil, DisableLevel, fmt.Sprintf(format, args...), l.base().NewLine, nil)
 }
 
 // Println prints a log message without levels and colors.
 // It adds a new line at the end, it overrides the `NewLine` option.
 func (l *Logger) Println(v ...any) {
-	l.print(DisableLevel, fmt.Sprint(v...), true, nil)
+	l.print(nil, DisableLevel, fmt.Sprint(v...), true, nil)
 }
 
 // splitArgsFields splits the given values to arguments and fields.
 // It returns the arguments and the fields.
 // It's used to separate the arguments from the fields
-// when a `Fields` or `[]slog.Attr` is passed as a value.
-func splitArgsFields(values []any) ([]any, Fields) {
+// when a `Fields`, `FieldList`, `[]slog.Attr` or `slog.Attr` is passed as a value.
+// Error values are kept as arguments and they are recorded
+// under the "error" field too, unless an "error" field is already passed.
+func splitArgsFields(values []any) ([]any, FieldList) {
 	var (
 		args   = values[:0]
-		fields Fields
+		fields FieldList
+		errs   []error
 	)
 
 	for _, value := range values {
 		switch f := value.(type) {
 		case Fields:
-			if fields == nil {
-				fields = make(Fields)
+			for _, field := range f.fieldList() {
+				fields = appendField(fields, field)
 			}
-
-			for k, v := range f {
-				fields[k] = v
+		case FieldList:
+			for _, field := range f {
+				fields = appendField(fields, field)
 			}
 		case []slog.Attr:
-			if fields == nil {
-				fields = make(Fields)
-			}
-
 			for _, attr := range f {
-				fields[attr.Key] = attr.Value.Any()
+				fields = appendField(fields, attr)
 			}
 		case slog.Attr: // a single slog attr.
-			if fields == nil {
-				fields = make(Fields)
-			}
-
-			fields[f.Key] = f.Value.Any()
+			fields = appendField(fields, f)
+		case Valuer: // a lazy argument, the level check is already passed.
+			args = append(args, f())
+		case error:
+			errs = append(errs, f)
+			args = append(args, value)
 		default:
 			args = append(args, value) // use it as fmt argument.
 		}
 	}
 
+	if len(errs) > 0 {
+		if _, exists := fields.Get("error"); !exists {
+			fields = appendField(fields, errorArgsField(errs))
+		}
+	}
+
 	return args, fields
 }
 
@@ -406,9 +659,16 @@ func splitArgsFields(values []any) ([]any, Fields) {
 // This method can be used to use custom log levels if needed.
 // It adds a new line in the end.
 func (l *Logger) Log(level Level, v ...any) {
-	if l.Level >= level {
+	l.LogContext(nil, level, v...)
+}
+
+// LogContext same as `Log` but it accepts a context too.
+// The context is passed to the registered context extractors
+// and it's available through the `Log.Context` field.
+func (l *Logger) LogContext(ctx context.Context, level Level, v ...any) {
+	if l.base().Level >= level {
 		args, fields := splitArgsFields(v)
-		l.print(level, fmt.Sprint(args...), l.NewLine, fields)
+		l.print(ctx, level, fmt.Sprint(args...), l.base().NewLine, fields)
 	}
 }
 
@@ -416,13 +676,13 @@ func (l *Logger) Log(level Level, v ...any) {
 // This method can be used to use custom log levels if needed.
 // It adds a new line in the end.
 func (l *Logger) Logf(level Level, format string, args ...any) {
-	if l.Level >= level {
+	if l.base().Level >= level {
 		arguments, fields := splitArgsFields(args)
 		msg := format
 		if len(arguments) > 0 {
 			msg = fmt.Sprintf(msg, arguments...)
 		}
-		l.print(level, msg, l.NewLine, fields)
+		l.print(nil, level, msg, l.base().NewLine, fields)
 	}
 }
 
@@ -487,6 +747,127 @@ func (l *Logger) Debugf(format string, args ...any) {
 	l.Logf(DebugLevel, format, args...)
 }
 
+// FatalContext same as `Fatal` but it accepts a context too.
+func (l *Logger) FatalContext(ctx context.Context, v ...any) {
+	l.LogContext(ctx, FatalLevel, v...)
+}
+
+// ErrorContext same as `Error` but it accepts a context too.
+func (l *Logger) ErrorContext(ctx context.Context, v ...any) {
+	l.LogContext(ctx, ErrorLevel, v...)
+}
+
+// WarnContext same as `Warn` but it accepts a context too.
+func (l *Logger) WarnContext(ctx context.Context, v ...any) {
+	l.LogContext(ctx, WarnLevel, v...)
+}
+
+// InfoContext same as `Info` but it accepts a context too.
+func (l *Logger) InfoContext(ctx context.Context, v ...any) {
+	l.LogContext(ctx, InfoLevel, v...)
+}
+
+// DebugContext same as `Debug` but it accepts a context too.
+func (l *Logger) DebugContext(ctx context.Context, v ...any) {
+	l.LogContext(ctx, DebugLevel, v...)
+}
+
+// Logw prints a leveled log message with fields to the output.
+// The "kv" are alternating string keys and values, e.g. "user_id", 42,
+// and `Field`, `Fields` or `slog.Attr` values.
+//
+// Usage:
+//
+//	logger.Logw(golog.InfoLevel, "request handled", "path", r.URL.Path, golog.Duration("took", took))
+//
+// The fields are not even parsed when the level is not enabled,
+// see `LogFields` for a call which does not allocate on disabled levels.
+func (l *Logger) Logw(level Level, msg string, kv ...any) {
+	if l.base().Level >= level {
+		l.print(nil, level, msg, l.base().NewLine, argsToFields(kv))
+	}
+}
+
+// LogFields prints a leveled log message with typed fields to the output.
+// Unlike `Logw`, the fields are not boxed into interface values,
+// therefore a call of a disabled level does not allocate at all.
+//
+// Usage:
+//
+//	logger.LogFields(golog.DebugLevel, "cache hit", golog.String("key", key), golog.Int("size", n))
+func (l *Logger) LogFields(level Level, msg string, fields ...Field) {
+	if l.base().Level >= level {
+		list := make(FieldList, 0, len(fields))
+		for _, f := range fields {
+			list = appendField(list, f)
+		}
+		l.print(nil, level, msg, l.base().NewLine, list)
+	}
+}
+
+// Logfn prints a leveled log message which is built by the "fn" function,
+// the "fn" is called only when the level is enabled.
+// It's useful for messages that are expensive to build.
+//
+// Usage:
+//
+//	logger.Debugfn(func() string { return dump(state) })
+func (l *Logger) Logfn(level Level, fn func() string) {
+	if l.base().Level >= level {
+		l.print(nil, level, fn(), l.base().NewLine, nil)
+	}
+}
+
+// Fatalfn same as `Logfn` with the `FatalLevel`, it will `os.Exit(1)` no matter the level of the logger.
+func (l *Logger) Fatalfn(fn func() string) {
+	l.Logfn(FatalLevel, fn)
+}
+
+// Errorfn same as `Logfn` with the `ErrorLevel`.
+func (l *Logger) Errorfn(fn func() string) {
+	l.Logfn(ErrorLevel, fn)
+}
+
+// Warnfn same as `Logfn` with the `WarnLevel`.
+func (l *Logger) Warnfn(fn func() string) {
+	l.Logfn(WarnLevel, fn)
+}
+
+// Infofn same as `Logfn` with the `InfoLevel`.
+func (l *Logger) Infofn(fn func() string) {
+	l.Logfn(InfoLevel, fn)
+}
+
+// Debugfn same as `Logfn` with the `DebugLevel`.
+func (l *Logger) Debugfn(fn func() string) {
+	l.Logfn(DebugLevel, fn)
+}
+
+// Fatalw same as `Logw` with the `FatalLevel`, it will `os.Exit(1)` no matter the level of the logger.
+func (l *Logger) Fatalw(msg string, kv ...any) {
+	l.Logw(FatalLevel, msg, kv...)
+}
+
+// Errorw same as `Logw` with the `ErrorLevel`.
+func (l *Logger) Errorw(msg string, kv ...any) {
+	l.Logw(ErrorLevel, msg, kv...)
+}
+
+// Warnw same as `Logw` with the `WarnLevel`.
+func (l *Logger) Warnw(msg string, kv ...any) {
+	l.Logw(WarnLevel, msg, kv...)
+}
+
+// Infow same as `Logw` with the `InfoLevel`.
+func (l *Logger) Infow(msg string, kv ...any) {
+	l.Logw(InfoLevel, msg, kv...)
+}
+
+// Debugw same as `Logw` with the `DebugLevel`.
+func (l *Logger) Debugw(msg string, kv ...any) {
+	l.Logw(DebugLevel, msg, kv...)
+}
+
 // Install receives  an external logger
 // and automatically adapts its print functions.
 //
@@ -528,9 +909,10 @@ func (l *Logger) Install(logger any) {
 // It stops on the handler which returns true firstly.
 // The `Log` value holds the level of the print operation as well.
 func (l *Logger) Handle(handler Handler) {
-	l.mu.Lock()
-	l.handlers = append(l.handlers, handler)
-	l.mu.Unlock()
+	c := l.base()
+	c.mu.Lock()
+	c.handlers = append(c.handlers, handler)
+	c.mu.Unlock()
 }
 
 func (l *Logger) handled(value *Log) (handled bool) {
@@ -548,7 +930,7 @@ func (l *Logger) handled(value *Log) (handled bool) {
 func (l *Logger) Scan(r io.Reader) (cancel func()) {
 	// Create a custom scanner that adds time formatting
 	scanner := &timeScanner{
-		logger: l,
+		logger: l.base(),
 		reader: r,
 	}
 	return scanner.scan()
@@ -604,35 +986,109 @@ func (ts *timeScanner) scan() (cancel func()) {
 
 // Clone returns a copy of this "l" Logger.
 // This copy is returned as pointer as well.
+// The copy of a derived Logger, see `With`, keeps its fields
+// but it does not share its parent's configuration.
 func (l *Logger) Clone() *Logger {
+	c := l.base()
+
 	// copy level output and format maps.
-	formats := make(map[string]Formatter, len(l.formatters))
-	maps.Copy(formats, l.formatters)
+	formats := make(map[string]Formatter, len(c.formatters))
+	maps.Copy(formats, c.formatters)
 
-	levelFormat := make(map[Level]Formatter, len(l.LevelFormatter))
-	maps.Copy(levelFormat, l.LevelFormatter)
+	levelFormat := make(map[Level]Formatter, len(c.LevelFormatter))
+	maps.Copy(levelFormat, c.LevelFormatter)
 
-	levelOutput := make(map[Level]io.Writer, len(l.LevelOutput))
-	maps.Copy(levelOutput, l.LevelOutput)
+	levelOutput := make(map[Level]io.Writer, len(c.LevelOutput))
+	maps.Copy(levelOutput, c.LevelOutput)
 
 	return &Logger{
-		Prefix:         l.Prefix,
-		Level:          l.Level,
-		TimeFormat:     l.TimeFormat,
-		NewLine:        l.NewLine,
-		Printer:        l.Printer.Clone(),
-		LevelOutput:    levelOutput,
-		formatter:      l.formatter,
-		formatters:     formats,
-		LevelFormatter: levelFormat,
-		handlers:       l.handlers,
-		children:       newLoggerMap(),
-		mu:             sync.RWMutex{},
+		Prefix:          c.Prefix,
+		Level:           c.Level,
+		TimeFormat:      c.TimeFormat,
+		StacktraceLimit: c.StacktraceLimit,
+		StacktraceLevel: c.StacktraceLevel,
+		ErrorStacktrace: c.ErrorStacktrace,
+		NewLine:         c.NewLine,
+		Printer:         c.Printer.Clone(),
+		LevelOutput:     levelOutput,
+		formatter:       c.formatter,
+		formatters:      formats,
+		LevelFormatter:  levelFormat,
+		handlers:        c.handlers,
+		children:        newLoggerMap(),
+		ReportCaller:    c.ReportCaller,
+		CallerSkip:      c.CallerSkip,
+		CallerFullPath:  c.CallerFullPath,
+		SortFields:      c.SortFields,
+		fields:          l.fields,
+		groups:          l.groups[:len(l.groups):len(l.groups)],
+		mu:              sync.RWMutex{},
+
+		contextExtractors: c.contextExtractors[:len(c.contextExtractors):len(c.contextExtractors)],
+	}
+}
+
+// derive returns a Logger which shares the configuration of "l", by pointer,
+// and carries the given fields and groups only.
+func (l *Logger) derive(fields FieldList, groups []string) *Logger {
+	c := l.base()
+	return &Logger{
+		Printer:  c.Printer,
+		root:     c,
+		children: newLoggerMap(),
+		fields:   fields,
+		groups:   groups,
 	}
 }
 
+// With returns a derived Logger which attaches the given fields
+// to every log it prints, on text, JSON and custom handlers alike.
+// The derived Logger shares its parent's configuration, i.e. level, output,
+// formatters and handlers, and it carries only its own fields and children:
+// later changes of the parent, e.g. `SetLevelOutput` or `SetFormat`, apply to it too
+// and its setters change the parent's configuration. Read the configuration
+// from the parent, the exported fields of a derived Logger are not used.
+//
+// Accepts one or more `Fields`, `slog.Attr`, `[]slog.Attr`
+// or slog-style alternating keys and values, e.g.
+//
+//	reqLogger := logger.With(golog.Fields{"service": "api", "tenant": "acme"})
+//	reqLogger = reqLogger.With("request_id", id)
+//	reqLogger.Info("request handled")
+//
+// The fields of the parent are kept, the new ones override them.
+func (l *Logger) With(args ...any) *Logger {
+	fields := argsToFields(args)
+	fields = mergeFields(l.fields, groupFields(l.groups, fields))
+	// any append should not modify the parent's ones.
+	return l.derive(fields[:len(fields):len(fields)], l.groups)
+}
+
+// WithGroup returns a derived Logger which nests all the following fields,
+// the ones passed to `With` and to the log methods, under the given group "name".
+// Text output prints them with dotted keys, i.e "http.method=GET",
+// and JSON output as nested objects.
+// The context extracted fields are not affected.
+//
+// Usage:
+//
+//	httpLogger := logger.WithGroup("http").With("method", r.Method)
+//	httpLogger.Info("request", golog.Fields{"path": r.URL.Path})
+//
+// If "name" is empty then it returns the same Logger.
+func (l *Logger) WithGroup(name string) *Logger {
+	if name == "" {
+		return l
+	}
+
+	groups := append(l.groups[:len(l.groups):len(l.groups)], name)
+	return l.derive(l.fields, groups)
+}
+
 // Child (creates if not exists and) returns a new child
 // Logger based on the current logger's fields.
+// The child of a derived Logger, see `With`, keeps its fields and groups
+// and it's registered to the derived Logger, not to its parent.
 //
 // Can be used to separate logs by category.
 // If the "key" is string then it's used as prefix,
@@ -648,6 +1104,7 @@ func (l *Logger) Child(key any) *Logger {
 // It does add the ": " in the end of "prefix" if it's missing.
 // It returns itself.
 func (l *Logger) SetChildPrefix(prefix string) *Logger {
+	c := l.base()
 	if prefix == "" {
 		return l
 	}
@@ -657,14 +1114,14 @@ func (l *Logger) SetChildPrefix(prefix string) *Logger {
 		prefix += ": "
 	}
 
-	l.mu.Lock()
-	if l.Prefix != "" {
-		if !strings.HasSuffix(l.Prefix, " ") {
-			l.Prefix += " "
+	c.mu.Lock()
+	if c.Prefix != "" {
+		if !strings.HasSuffix(c.Prefix, " ") {
+			c.Prefix += " "
 		}
 	}
-	l.Prefix += prefix
-	l.mu.Unlock()
+	c.Prefix += prefix
+	c.mu.Unlock()
 
 	return l
 }
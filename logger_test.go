@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLoggerChildOfDerived(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New().SetOutput(&buf).SetTimeFormat("")
+
+	derived := logger.With("service", "api").WithGroup("http")
+	child := derived.Child("router")
+	child.Info("handled", String("method", "GET"))
+
+	if expected, got := "[INFO] router: handled service=api http.method=GET\n", buf.String(); got != expected {
+		t.Fatalf("expected %q but got %q", expected, got)
+	}
+
+	if derived.Child("router") != child {
+		t.Fatal("expected the same child for the same key")
+	}
+
+	if n := derived.ChildCount(); n != 1 {
+		t.Fatalf("expected the derived logger to have 1 child but got %d", n)
+	}
+
+	if n := logger.ChildCount(); n != 0 {
+		t.Fatalf("expected the child to be registered to the derived logger only but the parent has %d", n)
+	}
+
+	// a child of the parent does not carry the derived fields.
+	buf.Reset()
+	logger.Child("router").Info("handled")
+	if expected, got := "[INFO] router: handled\n", buf.String(); got != expected {
+		t.Fatalf("expected %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,315 @@
+package golog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// MsgPackFormatter is a Formatter type for MessagePack logs,
+// a compact binary encoding for high-volume pipelines.
+// Each log is encoded as a map of its time, level, message, fields, caller and stacktrace,
+// the records are written one after the other. Times are encoded as
+// the timestamp extension type, with nanosecond precision.
+//
+// Use `DecodeMsgPack` to read the logs back.
+type MsgPackFormatter struct{}
+
+// String returns the name of the Formatter.
+// In this case it returns "msgpack".
+// It's used to map the formatter names with their implementations.
+func (f *MsgPackFormatter) String() string {
+	return "msgpack"
+}
+
+// Options returns a new MessagePack Formatter, it accepts no options.
+func (f *MsgPackFormatter) Options(opts ...any) Formatter {
+	return new(MsgPackFormatter)
+}
+
+// Format prints the logs in MessagePack format.
+//
+// Usage:
+// logger.SetFormat("msgpack") or
+// logger.SetLevelFormat("info", "msgpack")
+func (f *MsgPackFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	*bufPtr = appendBinaryLog(msgpackEncoding{}, *bufPtr, log)
+	_, err := dest.Write(*bufPtr)
+	return err == nil
+}
+
+// DecodeMsgPack decodes the logs written by the "msgpack" formatter.
+// The decoded logs have no `Logger`, errors are decoded as groups
+// of their "message", "type" and "causes" and durations as nanoseconds.
+func DecodeMsgPack(r io.Reader) ([]Log, error) {
+	return decodeBinaryLogs(msgpackEncoding{}, r)
+}
+
+// msgpackTimestamp is the type of the timestamp extension.
+const msgpackTimestamp = -1
+
+type msgpackEncoding struct{}
+
+// appendHead appends the header of a string, binary, array or map of "n" length.
+// The "fix" is the first byte of the fix format which holds lengths up to "fixMax",
+// the "head8" is the first byte of the 8-bit length format, zero if there isn't one,
+// and the "head16" is the first byte of the 16-bit length format, followed by the 32-bit one.
+func (msgpackEncoding) appendHead(buf []byte, n int, fix byte, fixMax int, head8, head16 byte) []byte {
+	switch {
+	case n <= fixMax:
+		return append(buf, fix|byte(n))
+	case n <= math.MaxUint8 && head8 != 0:
+		return append(buf, head8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, head16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, head16+1), uint32(n))
+	}
+}
+
+func (e msgpackEncoding) appendMap(buf []byte, n int) []byte {
+	return e.appendHead(buf, n, 0x80, 15, 0, 0xde)
+}
+
+func (e msgpackEncoding) appendArray(buf []byte, n int) []byte {
+	return e.appendHead(buf, n, 0x90, 15, 0, 0xdc)
+}
+
+func (e msgpackEncoding) appendString(buf []byte, s string) []byte {
+	return append(e.appendHead(buf, len(s), 0xa0, 31, 0xd9, 0xda), s...)
+}
+
+func (e msgpackEncoding) appendBytes(buf []byte, b []byte) []byte {
+	return append(e.appendHead(buf, len(b), 0, -1, 0xc4, 0xc5), b...)
+}
+
+func (e msgpackEncoding) appendInt(buf []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return e.appendUint(buf, uint64(n))
+	case n >= -32:
+		return append(buf, byte(n))
+	case n >= math.MinInt8:
+		return append(buf, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xd2), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xd3), uint64(n))
+	}
+}
+
+func (msgpackEncoding) appendUint(buf []byte, n uint64) []byte {
+	switch {
+	case n <= 127:
+		return append(buf, byte(n))
+	case n <= math.MaxUint8:
+		return append(buf, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xce), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xcf), n)
+	}
+}
+
+func (msgpackEncoding) appendFloat(buf []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(append(buf, 0xcb), math.Float64bits(f))
+}
+
+func (msgpackEncoding) appendBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 0xc3)
+	}
+
+	return append(buf, 0xc2)
+}
+
+func (msgpackEncoding) appendNil(buf []byte) []byte {
+	return append(buf, 0xc0)
+}
+
+func (msgpackEncoding) appendTime(buf []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint32(t.Nanosecond())
+	switch {
+	case sec>>34 == 0 && nsec == 0 && sec <= math.MaxUint32: // timestamp 32.
+		buf = append(buf, 0xd6, byte(0xff))
+		return binary.BigEndian.AppendUint32(buf, uint32(sec))
+	case sec>>34 == 0: // timestamp 64.
+		buf = append(buf, 0xd7, byte(0xff))
+		return binary.BigEndian.AppendUint64(buf, uint64(nsec)<<34|uint64(sec))
+	default: // timestamp 96.
+		buf = append(buf, 0xc7, 12, byte(0xff))
+		buf = binary.BigEndian.AppendUint32(buf, nsec)
+		return binary.BigEndian.AppendUint64(buf, uint64(sec))
+	}
+}
+
+func (e msgpackEncoding) decodeValue(r *bufio.Reader, depth int) (slog.Value, error) {
+	if depth > maxBinaryDepth {
+		return slog.Value{}, errBinaryDepth
+	}
+
+	c, err := r.ReadByte()
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	switch {
+	case c <= 0x7f: // positive fixint.
+		return slog.Int64Value(int64(c)), nil
+	case c >= 0xe0: // negative fixint.
+		return slog.Int64Value(int64(int8(c))), nil
+	case c&0xf0 == 0x80: // fixmap.
+		return e.decodeMap(r, uint64(c&0x0f), depth)
+	case c&0xf0 == 0x90: // fixarray.
+		return e.decodeArray(r, uint64(c&0x0f), depth)
+	case c&0xe0 == 0xa0: // fixstr.
+		b, err := readBinaryBytes(r, uint64(c&0x1f))
+		return slog.StringValue(string(b)), err
+	}
+
+	switch c {
+	case 0xc0:
+		return slog.AnyValue(nil), nil
+	case 0xc2:
+		return slog.BoolValue(false), nil
+	case 0xc3:
+		return slog.BoolValue(true), nil
+	case 0xc4, 0xc5, 0xc6: // bin.
+		n, err := e.readUint(r, 1<<(c-0xc4))
+		if err != nil {
+			return slog.Value{}, err
+		}
+		b, err := readBinaryBytes(r, n)
+		return slog.AnyValue(b), err
+	case 0xc7, 0xc8, 0xc9: // ext.
+		n, err := e.readUint(r, 1<<(c-0xc7))
+		if err != nil {
+			return slog.Value{}, err
+		}
+		return e.decodeExt(r, n)
+	case 0xca:
+		n, err := e.readUint(r, 4)
+		return slog.Float64Value(float64(math.Float32frombits(uint32(n)))), err
+	case 0xcb:
+		n, err := e.readUint(r, 8)
+		return slog.Float64Value(math.Float64frombits(n)), err
+	case 0xcc, 0xcd, 0xce, 0xcf: // uint.
+		n, err := e.readUint(r, 1<<(c-0xcc))
+		if n > math.MaxInt64 {
+			return slog.Uint64Value(n), err
+		}
+		return slog.Int64Value(int64(n)), err
+	case 0xd0, 0xd1, 0xd2, 0xd3: // int.
+		size := 1 << (c - 0xd0)
+		n, err := e.readUint(r, size)
+		shift := 64 - 8*size
+		return slog.Int64Value(int64(n<<shift) >> shift), err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8: // fixext.
+		return e.decodeExt(r, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb: // str.
+		n, err := e.readUint(r, 1<<(c-0xd9))
+		if err != nil {
+			return slog.Value{}, err
+		}
+		b, err := readBinaryBytes(r, n)
+		return slog.StringValue(string(b)), err
+	case 0xdc, 0xdd: // array.
+		n, err := e.readUint(r, 2<<(c-0xdc))
+		if err != nil {
+			return slog.Value{}, err
+		}
+		return e.decodeArray(r, n, depth)
+	case 0xde, 0xdf: // map.
+		n, err := e.readUint(r, 2<<(c-0xde))
+		if err != nil {
+			return slog.Value{}, err
+		}
+		return e.decodeMap(r, n, depth)
+	default:
+		return slog.Value{}, fmt.Errorf("golog: msgpack: unsupported format 0x%x", c)
+	}
+}
+
+// readUint reads a big-endian unsigned integer of "size" bytes.
+func (msgpackEncoding) readUint(r *bufio.Reader, size int) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[8-size:]); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+func (e msgpackEncoding) decodeArray(r *bufio.Reader, n uint64, depth int) (slog.Value, error) {
+	values := make([]slog.Value, 0, min(n, 1024))
+	for range n {
+		v, err := e.decodeValue(r, depth+1)
+		if err != nil {
+			return slog.Value{}, err
+		}
+		values = append(values, v)
+	}
+
+	return slog.AnyValue(values), nil
+}
+
+func (e msgpackEncoding) decodeMap(r *bufio.Reader, n uint64, depth int) (slog.Value, error) {
+	attrs := make([]slog.Attr, 0, min(n, 1024))
+	for range n {
+		key, err := e.decodeValue(r, depth+1)
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		v, err := e.decodeValue(r, depth+1)
+		if err != nil {
+			return slog.Value{}, err
+		}
+		attrs = append(attrs, slog.Attr{Key: key.String(), Value: v})
+	}
+
+	return slog.GroupValue(attrs...), nil
+}
+
+// decodeExt decodes an extension of "n" data bytes,
+// the timestamps are decoded as times and the rest as their data bytes.
+func (e msgpackEncoding) decodeExt(r *bufio.Reader, n uint64) (slog.Value, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	data, err := readBinaryBytes(r, n)
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	if int8(typ) != msgpackTimestamp {
+		return slog.AnyValue(data), nil
+	}
+
+	switch n {
+	case 4:
+		return slog.TimeValue(time.Unix(int64(binary.BigEndian.Uint32(data)), 0)), nil
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		return slog.TimeValue(time.Unix(int64(v&(1<<34-1)), int64(v>>34))), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(data)
+		sec := int64(binary.BigEndian.Uint64(data[4:]))
+		return slog.TimeValue(time.Unix(sec, int64(nsec))), nil
+	default:
+		return slog.Value{}, fmt.Errorf("golog: msgpack: invalid timestamp length %d", n)
+	}
+}
@@ -0,0 +1,236 @@
+package golog
+
+import (
+	"io"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTelFormatter is a Formatter type for OpenTelemetry logs, in the OTLP/JSON format.
+// Each log is written as an `ExportLogsServiceRequest` on a single line,
+// so the files can be fed to a collector's OTLP JSON file receiver as they are, e.g.
+//
+//	{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"api"}}]},
+//	"scopeLogs":[{"scope":{"name":"db"},"logRecords":[{"timeUnixNano":"1756059304123456000",
+//	"severityNumber":17,"severityText":"ERROR","body":{"stringValue":"query failed"},
+//	"attributes":[{"key":"table","value":{"stringValue":"users"}}],
+//	"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174"}]}]}]}
+//
+// The severity number is the `LevelMetadata.SeverityNumber`,
+// the scope name is the Logger's prefix. The "trace_id" (or "traceId")
+// and "span_id" (or "spanId") fields are written as the log's trace context,
+// e.g. through a `ContextExtractor`. The first error field, the stacktrace
+// and the caller are written as the OpenTelemetry semantic conventions' attributes,
+// i.e. "exception.type", "exception.message", "exception.stacktrace" and "code.*".
+type OTelFormatter struct {
+	// Resource holds the attributes of the resource which produces the logs,
+	// e.g. "service.name". They are written on every log.
+	Resource FieldList
+}
+
+// String returns the name of the Formatter.
+// In this case it returns "otel".
+// It's used to map the formatter names with their implementations.
+func (f *OTelFormatter) String() string {
+	return "otel"
+}
+
+// Options sets the resource attributes of the OpenTelemetry Formatter
+// and returns a new one. Accepts `Field`, `Fields`, `FieldList` or key/value pairs,
+// e.g. logger.SetFormat("otel", "service.name", "api", "service.version", "1.0.0").
+func (f *OTelFormatter) Options(opts ...any) Formatter {
+	return &OTelFormatter{
+		Resource: mergeFields(f.Resource, argsToFields(opts)),
+	}
+}
+
+// Format prints the logs in OTLP/JSON format.
+//
+// Usage:
+// logger.SetFormat("otel", "service.name", "api") or
+// logger.SetLevelFormat("error", "otel")
+func (f *OTelFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	buf := append(*bufPtr, `{"resourceLogs":[{"resource":{"attributes":[`...)
+	buf = appendOTelAttributes(buf, f.Resource, true)
+	buf = append(buf, `]},"scopeLogs":[{"scope":{`...)
+	if prefix := log.Prefix(); prefix != "" {
+		buf = append(buf, `"name":`...)
+		buf = appendJSON(buf, prefix)
+	}
+
+	buf = append(buf, `},"logRecords":[{"timeUnixNano":"`...)
+	buf = strconv.AppendInt(buf, log.Time.UnixNano(), 10)
+	buf = append(buf, '"')
+
+	if meta, ok := Levels[log.Level]; ok && log.Level != DisableLevel {
+		if meta.SeverityNumber > 0 {
+			buf = append(buf, `,"severityNumber":`...)
+			buf = strconv.AppendInt(buf, int64(meta.SeverityNumber), 10)
+		}
+		buf = append(buf, `,"severityText":`...)
+		buf = appendJSON(buf, strings.ToUpper(meta.Name))
+	}
+
+	buf = append(buf, `,"body":{"stringValue":`...)
+	buf = appendJSON(buf, log.Message)
+	buf = append(buf, `},"attributes":[`...)
+
+	var (
+		traceID, spanID string
+		errorInfo       *ErrorInfo
+		first           = true
+	)
+
+	for _, field := range log.Fields {
+		switch field.Key {
+		case "trace_id", "traceId":
+			traceID = field.Value.String()
+			continue
+		case "span_id", "spanId":
+			spanID = field.Value.String()
+			continue
+		}
+
+		if info, ok := field.Value.Any().(*ErrorInfo); ok && errorInfo == nil {
+			errorInfo = info
+			continue
+		}
+
+		buf = appendOTelAttribute(buf, field.Key, field.Value, first)
+		first = false
+	}
+
+	if errorInfo != nil {
+		buf = appendOTelAttribute(buf, "exception.type", slog.StringValue(errorInfo.Type), first)
+		buf = appendOTelAttribute(buf, "exception.message", slog.StringValue(errorInfo.Message), false)
+		first = false
+	}
+
+	if len(log.Stacktrace) > 0 {
+		buf = appendOTelAttribute(buf, "exception.stacktrace", slog.StringValue(stacktraceString(log.Stacktrace)), first)
+		first = false
+	}
+
+	if !log.Caller.IsZero() {
+		buf = appendOTelAttribute(buf, "code.file.path", slog.StringValue(log.Caller.File), first)
+		buf = appendOTelAttribute(buf, "code.line.number", slog.IntValue(log.Caller.Line), false)
+		buf = appendOTelAttribute(buf, "code.function.name", slog.StringValue(log.Caller.Function), false)
+	}
+	buf = append(buf, ']')
+
+	if traceID != "" {
+		buf = append(buf, `,"traceId":`...)
+		buf = appendJSON(buf, traceID)
+	}
+
+	if spanID != "" {
+		buf = append(buf, `,"spanId":`...)
+		buf = appendJSON(buf, spanID)
+	}
+
+	buf = append(buf, "}]}]}]}\n"...)
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+// appendOTelAttributes appends the "fields" as a list of OTLP key/value pairs.
+func appendOTelAttributes(buf []byte, fields FieldList, first bool) []byte {
+	for _, field := range fields {
+		buf = appendOTelAttribute(buf, field.Key, field.Value, first)
+		first = false
+	}
+
+	return buf
+}
+
+func appendOTelAttribute(buf []byte, key string, value slog.Value, first bool) []byte {
+	if !first {
+		buf = append(buf, ',')
+	}
+
+	buf = append(buf, `{"key":`...)
+	buf = appendJSON(buf, key)
+	buf = append(buf, `,"value":`...)
+	buf = appendOTelValue(buf, value)
+	return append(buf, '}')
+}
+
+// appendOTelValue appends the OTLP `AnyValue` of "v".
+func appendOTelValue(buf []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		buf = append(buf, `{"stringValue":`...)
+		buf = appendJSON(buf, v.String())
+	case slog.KindInt64:
+		buf = append(buf, `{"intValue":"`...)
+		buf = strconv.AppendInt(buf, v.Int64(), 10)
+		buf = append(buf, '"')
+	case slog.KindUint64:
+		buf = append(buf, `{"intValue":"`...)
+		buf = strconv.AppendUint(buf, v.Uint64(), 10)
+		buf = append(buf, '"')
+	case slog.KindFloat64:
+		buf = append(buf, `{"doubleValue":`...)
+		switch f := v.Float64(); {
+		case math.IsNaN(f):
+			buf = append(buf, `"NaN"`...)
+		case math.IsInf(f, 1):
+			buf = append(buf, `"Infinity"`...)
+		case math.IsInf(f, -1):
+			buf = append(buf, `"-Infinity"`...)
+		default:
+			buf = strconv.AppendFloat(buf, f, 'g', -1, 64)
+		}
+	case slog.KindBool:
+		buf = append(buf, `{"boolValue":`...)
+		buf = strconv.AppendBool(buf, v.Bool())
+	case slog.KindDuration:
+		buf = append(buf, `{"intValue":"`...)
+		buf = strconv.AppendInt(buf, int64(v.Duration()), 10)
+		buf = append(buf, '"')
+	case slog.KindTime:
+		buf = append(buf, `{"stringValue":`...)
+		buf = appendJSON(buf, v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		buf = append(buf, `{"kvlistValue":{"values":[`...)
+		buf = appendOTelAttributes(buf, v.Group(), true)
+		buf = append(buf, "]}"...)
+	default:
+		return appendOTelAny(buf, v.Any())
+	}
+
+	return append(buf, '}')
+}
+
+func appendOTelAny(buf []byte, v any) []byte {
+	switch value := v.(type) {
+	case []byte:
+		buf = append(buf, `{"bytesValue":`...)
+		buf = appendJSON(buf, value)
+		return append(buf, '}')
+	case error:
+		return appendOTelValue(buf, slog.StringValue(value.Error()))
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		buf = append(buf, `{"arrayValue":{"values":[`...)
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendOTelValue(buf, fieldValue(rv.Index(i).Interface()))
+		}
+		return append(buf, "]}}"...)
+	}
+
+	return appendOTelValue(buf, slog.StringValue(slog.AnyValue(v).String()))
+}
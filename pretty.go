@@ -0,0 +1,388 @@
+package golog
+
+import (
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kataras/golog/printer"
+)
+
+const (
+	// DefaultPrettyTimeFormat is the compact time format of the pretty formatter.
+	DefaultPrettyTimeFormat = "15:04:05.000"
+	// PrettyRelativeTime is a `PrettyFormatter.TimeFormat` value
+	// which prints the time elapsed since the program started, e.g. "+12.345s".
+	PrettyRelativeTime = "relative"
+)
+
+// startTime is the time the program started, see `PrettyRelativeTime`.
+var startTime = time.Now()
+
+// PrettyFormatter is a Formatter type for human-readable logs, meant for local development.
+// The time, level, caller and message columns are aligned,
+// the fields are written on the same line when they fit in the terminal width,
+// otherwise each one on its own line. Multi-line messages and values,
+// the error causes and the stacktrace are indented under the message.
+//
+// Colors are written to the outputs which support them only, see `printer.SupportsColor`.
+// The time is omitted when the Logger's `TimeFormat` is empty.
+//
+// Usage:
+//
+//	logger.SetFormat("pretty")
+//	logger.SetFormat("pretty", golog.PrettyRelativeTime)
+type PrettyFormatter struct {
+	// TimeFormat is the format of the time column,
+	// defaults to `DefaultPrettyTimeFormat`. See `PrettyRelativeTime` too.
+	TimeFormat string
+	// Width is the maximum width of a line before the fields are written
+	// on separate lines. Defaults to the terminal width, if the output is not
+	// a terminal then the fields are always written on the same line.
+	Width int
+
+	callerWidth atomic.Int32 // the width of the widest caller so far, keeps the columns aligned.
+	levelWidth  atomic.Int32 // the width of the longest level name, computed once.
+}
+
+// maxPrettyCallerWidth is the maximum width of the caller column alignment,
+// longer callers are written as they are.
+const maxPrettyCallerWidth = 32
+
+// String returns the name of the Formatter.
+// In this case it returns "pretty".
+// It's used to map the formatter names with their implementations.
+func (f *PrettyFormatter) String() string {
+	return "pretty"
+}
+
+// Options sets the options for the pretty Formatter and returns a new one.
+// A string option sets the `TimeFormat` and an int one the `Width`.
+func (f *PrettyFormatter) Options(opts ...any) Formatter {
+	formatter := &PrettyFormatter{
+		TimeFormat: f.TimeFormat,
+		Width:      f.Width,
+	}
+
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case string:
+			formatter.TimeFormat = v
+		case int:
+			formatter.Width = v
+		}
+	}
+
+	formatter.levelWidth.Store(int32(prettyLevelWidth()))
+	return formatter
+}
+
+// Format prints the logs in a human-readable format.
+//
+// Usage:
+// logger.SetFormat("pretty") or
+// logger.SetLevelFormat("debug", "pretty")
+func (f *PrettyFormatter) Format(dest io.Writer, log *Log) bool {
+	width := f.Width
+	if width <= 0 {
+		width = printer.TerminalWidth(dest)
+	}
+
+	plainPtr := acquireBuffer()
+	defer releaseBuffer(plainPtr)
+	*plainPtr = f.append(*plainPtr, log, width, false)
+
+	var err error
+	if printer.SupportsColor(dest) {
+		richPtr := acquireBuffer()
+		defer releaseBuffer(richPtr)
+		*richPtr = f.append(*richPtr, log, width, true)
+
+		_, err = printer.WriteRichBytes(dest, *richPtr, *plainPtr)
+	} else {
+		_, err = dest.Write(*plainPtr)
+	}
+
+	return err == nil
+}
+
+// Colors of the pretty output parts.
+const (
+	prettyGray   = 90
+	prettyKey    = printer.Cyan
+	prettyNumber = printer.Magenta
+	prettyError  = printer.Red
+)
+
+type prettyWriter struct {
+	buf     []byte
+	colored bool
+	column  int // the visible width of the current line.
+}
+
+func (w *prettyWriter) write(s string) {
+	w.buf = append(w.buf, s...)
+	w.advance(s)
+}
+
+// advance moves the current column by the visible text "s".
+func (w *prettyWriter) advance(s string) {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		w.column = utf8.RuneCountInString(s[i+1:])
+	} else {
+		w.column += utf8.RuneCountInString(s)
+	}
+}
+
+func (w *prettyWriter) color(s string, code int, style ...printer.RichOption) {
+	if !w.colored || code <= 0 || s == "" {
+		w.write(s)
+		return
+	}
+
+	w.buf = append(w.buf, printer.Rich(s, code, style...)...)
+	w.advance(s)
+}
+
+func (w *prettyWriter) pad(n int) {
+	for ; n > 0; n-- {
+		w.write(" ")
+	}
+}
+
+// newLine starts a new line, indented by "indent" spaces.
+func (w *prettyWriter) newLine(indent int) {
+	w.write("\n")
+	w.pad(indent)
+}
+
+// writeIndented writes "s", its lines after the first one are indented by "indent" spaces.
+func (w *prettyWriter) writeIndented(s string, indent int, code int) {
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			w.newLine(indent)
+		}
+		w.color(line, code)
+	}
+}
+
+func (f *PrettyFormatter) append(buf []byte, log *Log, width int, colored bool) []byte {
+	w := &prettyWriter{buf: buf, colored: colored}
+
+	if t := f.formatTime(log); t != "" {
+		w.color(t, prettyGray)
+		w.write(" ")
+	}
+
+	levelWidth := f.alignLevel()
+	if log.Level != DisableLevel {
+		name := strings.ToUpper(log.Level.String())
+		if level, ok := Levels[log.Level]; ok {
+			w.color(name, level.ColorCode, level.Style...)
+		} else {
+			w.write(name)
+		}
+		w.pad(levelWidth - utf8.RuneCountInString(name) + 1)
+	} else {
+		w.pad(levelWidth + 1)
+	}
+
+	if !log.Caller.IsZero() {
+		caller := log.Caller.ShortSource()
+		if log.Logger.CallerFullPath {
+			caller = log.Caller.Source
+		}
+
+		callerWidth := f.alignCaller(utf8.RuneCountInString(caller))
+		w.color(caller, prettyGray)
+		w.pad(callerWidth - utf8.RuneCountInString(caller) + 1)
+	}
+
+	indent := w.column
+	if prefix := log.Logger.Prefix; prefix != "" {
+		w.color(prefix, printer.White, printer.Bold)
+	}
+	w.writeIndented(log.Message, indent, 0)
+
+	fieldIndent := indent + 2
+	fieldsWidth, inline := prettyFieldsWidth("", log.Fields)
+	inline = inline && (width <= 0 || w.column+fieldsWidth <= width)
+	writePrettyFields(w, "", log.Fields, fieldIndent, inline)
+
+	if len(log.Stacktrace) > 0 {
+		w.newLine(fieldIndent)
+		w.color("stacktrace:", prettyGray)
+		for _, frame := range log.Stacktrace {
+			w.newLine(fieldIndent + 2)
+			w.write(frame.Function)
+			w.newLine(fieldIndent + 6)
+			w.color(frame.Source, prettyGray)
+		}
+	}
+
+	w.write("\n")
+	return w.buf
+}
+
+func (f *PrettyFormatter) formatTime(log *Log) string {
+	if log.Logger.TimeFormat == "" {
+		return ""
+	}
+
+	switch f.TimeFormat {
+	case "":
+		return log.Time.Format(DefaultPrettyTimeFormat)
+	case PrettyRelativeTime:
+		elapsed := log.Time.Sub(startTime).Seconds()
+		t := "+" + strconv.FormatFloat(elapsed, 'f', 3, 64) + "s"
+		if n := 10 - len(t); n > 0 { // keep the column aligned up to 99999s.
+			t = strings.Repeat(" ", n) + t
+		}
+		return t
+	default:
+		return log.Time.Format(f.TimeFormat)
+	}
+}
+
+// alignCaller returns the width of the caller column
+// and records the "n" width if it's the widest one so far.
+func (f *PrettyFormatter) alignCaller(n int) int {
+	if n > maxPrettyCallerWidth {
+		return n
+	}
+
+	for {
+		current := int(f.callerWidth.Load())
+		if n <= current {
+			return current
+		}
+
+		if f.callerWidth.CompareAndSwap(int32(current), int32(n)) {
+			return n
+		}
+	}
+}
+
+// alignLevel returns the width of the level column, the longest level name
+// when the formatter was created, see `Options`, or first used.
+func (f *PrettyFormatter) alignLevel() int {
+	if width := f.levelWidth.Load(); width > 0 {
+		return int(width)
+	}
+
+	width := prettyLevelWidth()
+	f.levelWidth.Store(int32(width))
+	return width
+}
+
+// prettyLevelWidth returns the width of the longest level name.
+func prettyLevelWidth() int {
+	width := 0
+	for level, meta := range Levels {
+		if level == DisableLevel {
+			continue
+		}
+
+		if n := utf8.RuneCountInString(meta.Name); n > width {
+			width = n
+		}
+	}
+
+	return width
+}
+
+// prettyFieldsWidth returns the width the "fields" take on a single line,
+// or false if any of them has to be written on more lines.
+func prettyFieldsWidth(prefix string, fields FieldList) (int, bool) {
+	width := 0
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			n, ok := prettyFieldsWidth(prefix+field.Key+".", field.Value.Group())
+			if !ok {
+				return 0, false
+			}
+			width += n
+			continue
+		}
+
+		value, _ := prettyValue(field.Value)
+		if strings.Contains(value, "\n") {
+			return 0, false
+		}
+
+		if info, ok := field.Value.Any().(*ErrorInfo); ok && len(info.Causes) > 0 {
+			return 0, false
+		}
+
+		width += 2 + len(prefix) + utf8.RuneCountInString(field.Key) + 1 + utf8.RuneCountInString(value)
+	}
+
+	return width, true
+}
+
+// writePrettyFields writes the "fields" on the current line if "inline" is true,
+// otherwise each one on a new line indented by "indent" spaces.
+func writePrettyFields(w *prettyWriter, prefix string, fields FieldList, indent int, inline bool) {
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			writePrettyFields(w, prefix+field.Key+".", field.Value.Group(), indent, inline)
+			continue
+		}
+
+		if inline {
+			w.write("  ")
+		} else {
+			w.newLine(indent)
+		}
+
+		w.color(prefix+field.Key, prettyKey)
+		w.color("=", prettyGray)
+
+		value, code := prettyValue(field.Value)
+		if strings.Contains(value, "\n") {
+			w.newLine(indent + 4)
+			w.writeIndented(value, indent+4, code)
+		} else {
+			w.color(value, code)
+		}
+
+		if info, ok := field.Value.Any().(*ErrorInfo); ok {
+			writePrettyCauses(w, info.Causes, indent+2)
+		}
+	}
+}
+
+func writePrettyCauses(w *prettyWriter, causes []*ErrorInfo, indent int) {
+	for _, cause := range causes {
+		w.newLine(indent)
+		w.color("caused by: ", prettyGray)
+		w.writeIndented(cause.Type+": "+cause.Message, indent+11, prettyError)
+		writePrettyCauses(w, cause.Causes, indent+2)
+	}
+}
+
+// prettyValue returns the text of a field's value and its color code.
+func prettyValue(v slog.Value) (string, int) {
+	switch v.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64, slog.KindBool, slog.KindDuration:
+		return v.String(), prettyNumber
+	case slog.KindString:
+		s := v.String()
+		if !strings.Contains(s, "\n") && logfmtNeedsQuote(s) {
+			s = strconv.Quote(s)
+		}
+		return s, 0
+	case slog.KindGroup:
+		return "", 0
+	}
+
+	if err, ok := v.Any().(error); ok {
+		return err.Error(), prettyError
+	}
+
+	return v.String(), 0
+}
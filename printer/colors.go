@@ -83,12 +83,32 @@ func WriteRich(w io.Writer, text string, colorCode int, options ...RichOption) (
 	return w.Write([]byte(text)) // else plain text.
 }
 
+// WriteRichBytes writes the "rich" data to the writer if it supports rich text,
+// otherwise the "plain" one. If "w" is a Printer then
+// each of its writers is checked separately.
+func WriteRichBytes(w io.Writer, rich, plain []byte) (int, error) {
+	if p, ok := w.(*Printer); ok {
+		return p.WriteRichBytes(rich, plain)
+	}
+
+	if SupportsColor(w) {
+		return w.Write(rich)
+	}
+
+	return w.Write(plain)
+}
+
 // SupportsColor determines if the output supports ANSI color codes.
+// If "w" is a Printer then it reports whether any of its writers does.
 func SupportsColor(w io.Writer) bool {
 	if w == nil {
 		return false
 	}
 
+	if p, ok := w.(*Printer); ok {
+		return p.SupportsColor()
+	}
+
 	isTerminal := !IsNop(w) && terminal.IsTerminal(w)
 	if isTerminal && runtime.GOOS == "windows" {
 		// if on windows then return true only when it does support 256-bit colors,
@@ -99,6 +119,21 @@ func SupportsColor(w io.Writer) bool {
 	return isTerminal
 }
 
+// TerminalWidth returns the number of columns of the terminal "w" writes to,
+// or zero if it's not a terminal. If "w" is a Printer then
+// the smallest width of its terminal writers is returned.
+func TerminalWidth(w io.Writer) int {
+	if w == nil {
+		return 0
+	}
+
+	if p, ok := w.(*Printer); ok {
+		return p.TerminalWidth()
+	}
+
+	return terminal.Width(w)
+}
+
 // NopOutput returns a writer that discards all writes.
 func NopOutput() io.Writer {
 	return &nopOutput{}
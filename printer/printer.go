@@ -55,6 +55,15 @@ func (p *Printer) AddOutput(writers ...io.Writer) {
 	p.mu.Unlock()
 }
 
+// Writers returns a copy of the printer's writers.
+func (p *Printer) Writers() []io.Writer {
+	p.mu.Lock()
+	writers := make([]io.Writer, len(p.writers))
+	copy(writers, p.writers)
+	p.mu.Unlock()
+	return writers
+}
+
 // Terminal returns a new Printer that includes the writers that output destination is a terminal kind.
 // If no terminal writers exist, it returns nil and false.
 func (p *Printer) Terminal() (*Printer, bool) {
@@ -135,6 +144,86 @@ func (p *Printer) WriteRich(text string, colorCode int, options ...RichOption) (
 	return n, lastErr
 }
 
+// SupportsColor reports whether any of the registered writers supports rich text.
+func (p *Printer) SupportsColor() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range p.writers {
+		if p.rich[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TerminalWidth returns the smallest number of columns
+// of the registered terminal writers, or zero if none of them is a terminal.
+func (p *Printer) TerminalWidth() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	width := 0
+	for _, w := range p.writers {
+		if n := TerminalWidth(w); n > 0 && (width == 0 || n < width) {
+			width = n
+		}
+	}
+
+	return width
+}
+
+// WriteRichBytes writes the "rich" data to the writers which support rich text
+// and the "plain" data to the rest of them, atomically.
+func (p *Printer) WriteRichBytes(rich, plain []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var lastErr error
+	var n int
+
+	for i, w := range p.writers {
+		data := plain
+		if p.rich[i] {
+			data = rich
+		}
+
+		written, err := w.Write(data)
+		if err != nil {
+			lastErr = err
+		}
+		if written > n {
+			n = written
+		}
+	}
+
+	return n, lastErr
+}
+
+// WriteEach writes the data returned by the "data" function
+// to each one of the writers, atomically. It's useful when the data
+// depends on the writer, e.g. a header written to the empty ones only.
+func (p *Printer) WriteEach(data func(w io.Writer) []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var lastErr error
+	var n int
+
+	for _, w := range p.writers {
+		written, err := w.Write(data(w))
+		if err != nil {
+			lastErr = err
+		}
+		if written > n {
+			n = written
+		}
+	}
+
+	return n, lastErr
+}
+
 // Write writes data to all registered writers atomically.
 func (p *Printer) Write(data []byte) (int, error) {
 	if len(data) == 0 {
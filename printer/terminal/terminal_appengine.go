@@ -9,3 +9,9 @@ import "io"
 func IsTerminal(f io.Writer) bool {
 	return true
 }
+
+// Width returns the number of columns of the terminal,
+// it's always zero as it can't be detected.
+func Width(f io.Writer) int {
+	return 0
+}
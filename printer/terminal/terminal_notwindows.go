@@ -22,3 +22,22 @@ func IsTerminal(f io.Writer) bool {
 		return false
 	}
 }
+
+// Width returns the number of columns of the terminal,
+// or zero if the given writer is not a terminal.
+func Width(f io.Writer) int {
+	v, ok := f.(*os.File)
+	if !ok {
+		return 0
+	}
+
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	_, _, err := syscall.Syscall(syscall.SYS_IOCTL, uintptr(v.Fd()), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws)))
+	if err != 0 {
+		return 0
+	}
+
+	return int(ws.Col)
+}
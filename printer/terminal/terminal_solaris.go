@@ -20,3 +20,19 @@ func IsTerminal(f io.Writer) bool {
 		return false
 	}
 }
+
+// Width returns the number of columns of the terminal,
+// or zero if the given writer is not a terminal.
+func Width(f io.Writer) int {
+	v, ok := f.(*os.File)
+	if !ok {
+		return 0
+	}
+
+	ws, err := unix.IoctlGetWinsize(int(v.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+
+	return int(ws.Col)
+}
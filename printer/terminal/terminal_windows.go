@@ -112,3 +112,19 @@ func IsTerminal(f io.Writer) bool {
 	err := windows.GetConsoleMode(windows.Handle(file.Fd()), &mode)
 	return err == nil
 }
+
+// Width returns the number of columns of the console window,
+// or zero if the given writer is not a console.
+func Width(f io.Writer) int {
+	file, ok := f.(*os.File)
+	if !ok {
+		return 0
+	}
+
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(file.Fd()), &info); err != nil {
+		return 0
+	}
+
+	return int(info.Window.Right-info.Window.Left) + 1
+}
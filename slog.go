@@ -0,0 +1,89 @@
+package golog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a `slog.Handler` which renders the slog records
+// through a golog Logger, its levels, colors, printer,
+// per-level outputs, formatters and handlers.
+//
+// Use `NewSlogHandler` to create a new one.
+type SlogHandler struct {
+	logger *Logger
+	fields FieldList // the fields added by WithAttrs.
+	groups []string  // the groups opened by WithGroup, the next fields are nested under.
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler returns a new `slog.Handler` which prints through the given "logger".
+// If "logger" is nil then the package-level `Default` logger is used instead.
+//
+// Usage:
+//
+//	slog.SetDefault(slog.New(golog.NewSlogHandler(golog.Default)))
+//
+// Note that the "logger" should not `Install` the same slog Logger,
+// otherwise it will end up in an infinite loop.
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	if logger == nil {
+		logger = Default
+	}
+
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled reports whether the golog Logger's level allows the given slog "level".
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	c := h.logger.base()
+	c.mu.RLock()
+	enabled := c.Level >= getGologLevel(level)
+	c.mu.RUnlock()
+	return enabled
+}
+
+// Handle prints the slog record through the golog Logger.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var fields FieldList
+	if n := r.NumAttrs(); n > 0 {
+		fields = make(FieldList, 0, n)
+		r.Attrs(func(attr slog.Attr) bool {
+			fields = appendField(fields, attr)
+			return true
+		})
+	}
+
+	fields = mergeFields(h.fields, groupFields(h.groups, fields))
+	h.logger.printAt(ctx, getGologLevel(r.Level), r.Message, h.logger.base().NewLine, fields, r.Time, r.PC)
+	return nil
+}
+
+// WithAttrs returns a new SlogHandler which prints the given "attrs" on every record.
+// The new handler keeps printing through the same Logger,
+// so level and output changes are respected.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make(FieldList, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendField(fields, attr)
+	}
+
+	fields = mergeFields(h.fields, groupFields(h.groups, fields))
+	return &SlogHandler{logger: h.logger, fields: fields, groups: h.groups}
+}
+
+// WithGroup returns a new SlogHandler which qualifies all the following attributes by the given group "name".
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]string, len(h.groups), len(h.groups)+1)
+	copy(groups, h.groups)
+	return &SlogHandler{logger: h.logger, fields: h.fields, groups: append(groups, name)}
+}
@@ -0,0 +1,440 @@
+package golog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// SyslogFacility is the facility of the syslog messages.
+type SyslogFacility int
+
+// The syslog facilities, the zero value is the SyslogUser.
+const (
+	SyslogKern SyslogFacility = iota - 1
+	_
+	SyslogUser
+	SyslogMail
+	SyslogDaemon
+	SyslogAuth
+	SyslogSyslog
+	SyslogLPR
+	SyslogNews
+	SyslogUUCP
+	SyslogCron
+	SyslogAuthPriv
+	SyslogFTP
+	SyslogNTP
+	SyslogAudit
+	SyslogAlert
+	SyslogClock
+	SyslogLocal0
+	SyslogLocal1
+	SyslogLocal2
+	SyslogLocal3
+	SyslogLocal4
+	SyslogLocal5
+	SyslogLocal6
+	SyslogLocal7
+)
+
+// code returns the facility's code of the PRI.
+func (f SyslogFacility) code() int {
+	switch {
+	case f == SyslogKern:
+		return 0
+	case f == 0:
+		return int(SyslogUser)
+	default:
+		return int(f)
+	}
+}
+
+// SyslogFormat is the format of the syslog messages.
+type SyslogFormat int
+
+const (
+	// SyslogRFC5424 is the format of the RFC 5424, the default one.
+	SyslogRFC5424 SyslogFormat = iota
+	// SyslogRFC3164 is the legacy BSD format of the RFC 3164,
+	// the fields are appended to the message as logfmt pairs.
+	SyslogRFC3164
+)
+
+// DefaultSyslogSDID is the default SD-ID of the structured data element of the fields.
+const DefaultSyslogSDID = "golog@32473"
+
+// SyslogOptions holds the options of the syslog messages, see `SyslogFormatter`.
+type SyslogOptions struct {
+	// Format is the format of the messages, defaults to SyslogRFC5424.
+	Format SyslogFormat
+	// Facility is the facility of the messages, defaults to SyslogUser.
+	Facility SyslogFacility
+	// Hostname is the name of the host, defaults to the `os.Hostname`.
+	Hostname string
+	// AppName is the name of the application, defaults to the base name of the executable.
+	AppName string
+	// MsgID is the type of the messages, defaults to the Logger's prefix.
+	MsgID string
+	// SDID is the SD-ID of the structured data element of the fields,
+	// defaults to DefaultSyslogSDID.
+	SDID string
+}
+
+func (opts SyslogOptions) withDefaults() SyslogOptions {
+	if opts.Hostname == "" {
+		opts.Hostname = hostname()
+	}
+
+	if opts.AppName == "" {
+		opts.AppName = filepath.Base(os.Args[0])
+	}
+
+	if opts.SDID == "" {
+		opts.SDID = DefaultSyslogSDID
+	}
+
+	return opts
+}
+
+// SyslogFormatter is a Formatter type for syslog messages, e.g.
+//
+//	<11>1 2025-08-24T18:15:04.123456+03:00 api-1 api 4242 db [golog@32473 table="users" status="500"] query failed
+//
+// The PRI is computed from the facility and the syslog severity of the log's level,
+// which is based on its `LevelMetadata.SeverityNumber`.
+// The fields are written as the parameters of a structured data element,
+// nested keys are separated by a dot.
+//
+// Pair it with a `SyslogOutput` to send the logs to a syslog server.
+type SyslogFormatter struct {
+	// Config holds the options of the messages.
+	Config SyslogOptions
+}
+
+// String returns the name of the Formatter.
+// In this case it returns "syslog".
+// It's used to map the formatter names with their implementations.
+func (f *SyslogFormatter) String() string {
+	return "syslog"
+}
+
+// Options sets the options for the syslog Formatter and returns a new one.
+// Accepts a `SyslogOptions`, a `SyslogFormat` or a `SyslogFacility` value.
+func (f *SyslogFormatter) Options(opts ...any) Formatter {
+	formatter := &SyslogFormatter{Config: f.Config}
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case SyslogOptions:
+			formatter.Config = v
+		case SyslogFormat:
+			formatter.Config.Format = v
+		case SyslogFacility:
+			formatter.Config.Facility = v
+		}
+	}
+
+	formatter.Config = formatter.Config.withDefaults()
+	return formatter
+}
+
+// Format prints the logs in syslog format.
+//
+// Usage:
+// logger.SetFormat("syslog") or
+// logger.SetFormat("syslog", golog.SyslogRFC3164, golog.SyslogLocal0)
+func (f *SyslogFormatter) Format(dest io.Writer, log *Log) bool {
+	bufPtr := acquireBuffer()
+	defer releaseBuffer(bufPtr)
+
+	buf := appendSyslog(*bufPtr, f.Config.withDefaults(), log)
+	buf = append(buf, '\n')
+	*bufPtr = buf
+
+	_, err := dest.Write(buf)
+	return err == nil
+}
+
+var pid = strconv.Itoa(os.Getpid())
+
+func appendSyslog(buf []byte, opts SyslogOptions, log *Log) []byte {
+	buf = append(buf, '<')
+	buf = strconv.AppendInt(buf, int64(opts.Facility.code()*8+log.Level.syslogSeverity()), 10)
+	buf = append(buf, '>')
+
+	msgID := opts.MsgID
+	if msgID == "" {
+		msgID = log.Prefix()
+	}
+
+	if opts.Format == SyslogRFC3164 {
+		buf = log.Time.AppendFormat(buf, time.Stamp)
+		buf = append(buf, ' ')
+		buf = append(buf, syslogHeaderValue(opts.Hostname, 255)...)
+		buf = append(buf, ' ')
+		buf = append(buf, syslogHeaderValue(opts.AppName, 32)...)
+		buf = append(buf, '[')
+		buf = append(buf, pid...)
+		buf = append(buf, "]: "...)
+		if msgID != "" {
+			buf = append(buf, msgID...)
+			buf = append(buf, ": "...)
+		}
+		buf = append(buf, log.Message...)
+		return appendLogfmtFields(buf, "", log.Fields)
+	}
+
+	buf = append(buf, "1 "...)
+	buf = log.Time.AppendFormat(buf, "2006-01-02T15:04:05.000000Z07:00")
+	for _, value := range [...]struct {
+		value string
+		max   int
+	}{{opts.Hostname, 255}, {opts.AppName, 48}, {pid, 128}, {msgID, 32}} {
+		buf = append(buf, ' ')
+		buf = append(buf, syslogHeaderValue(value.value, value.max)...)
+	}
+
+	buf = append(buf, ' ')
+	if len(log.Fields) == 0 {
+		buf = append(buf, '-')
+	} else {
+		buf = append(buf, '[')
+		buf = append(buf, opts.SDID...)
+		buf = appendSyslogParams(buf, "", log.Fields)
+		buf = append(buf, ']')
+	}
+
+	if log.Message != "" {
+		buf = append(buf, ' ')
+		buf = append(buf, log.Message...)
+	}
+
+	return buf
+}
+
+// syslogHeaderValue returns the header "value" as printable US-ASCII without spaces,
+// up to "max" characters, or "-" if it's empty.
+func syslogHeaderValue(value string, max int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+
+	if len(value) > max {
+		value = value[:max]
+	}
+
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func appendSyslogParams(buf []byte, prefix string, fields FieldList) []byte {
+	for _, field := range fields {
+		if field.Value.Kind() == slog.KindGroup {
+			buf = appendSyslogParams(buf, prefix+field.Key+".", field.Value.Group())
+			continue
+		}
+
+		name := strings.Map(func(r rune) rune {
+			if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+				return '_'
+			}
+			return r
+		}, prefix+field.Key)
+		if len(name) > 32 {
+			name = name[:32]
+		}
+
+		buf = append(buf, ' ')
+		buf = append(buf, name...)
+		buf = append(buf, `="`...)
+		for _, r := range field.Value.String() {
+			if r == '"' || r == '\\' || r == ']' {
+				buf = append(buf, '\\')
+			}
+			buf = utf8.AppendRune(buf, r)
+		}
+		buf = append(buf, '"')
+	}
+
+	return buf
+}
+
+// DefaultSyslogAddress is the local syslog socket, see `NewSyslogOutput`.
+const DefaultSyslogAddress = "/dev/log"
+
+// SyslogOutputOptions holds the transport options of a `SyslogOutput`,
+// the messages are configured by the `SyslogFormatter`.
+type SyslogOutputOptions struct {
+	// TLSConfig is the TLS configuration of the "tls" network.
+	TLSConfig *tls.Config
+}
+
+// SyslogOutput is an `io.Writer` which sends syslog messages to a syslog server,
+// the local unix socket or a remote one over UDP, TCP or TLS.
+// The TCP and TLS messages are framed by octet counting (RFC 6587).
+// Each Write sends one message, the trailing new line written by the `SyslogFormatter` is removed.
+// A failed write is retried once over a new connection.
+//
+// Usage:
+//
+//	output, err := golog.NewSyslogOutput("tcp", "logs.example.com:514", golog.SyslogOutputOptions{})
+//	logger.SetOutput(output).SetFormat("syslog", golog.SyslogLocal0)
+type SyslogOutput struct {
+	network string
+	address string
+	opts    SyslogOutputOptions
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// NewSyslogOutput returns a new syslog output which sends the messages to the "address"
+// of the given "network": "udp", "tcp", "tls", "unix" or "unixgram".
+// An empty "network" connects to the local syslog socket,
+// the `DefaultSyslogAddress` if "address" is empty too.
+//
+// The "opts" are used by the "tls" network only, see `SyslogOutputOptions.TLSConfig`.
+// The messages are formatted by the Logger's formatter, e.g. logger.SetFormat("syslog").
+func NewSyslogOutput(network, address string, opts SyslogOutputOptions) (*SyslogOutput, error) {
+	switch network {
+	case "", "unix", "unixgram":
+		if address == "" {
+			address = DefaultSyslogAddress
+		}
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "tls":
+	default:
+		return nil, fmt.Errorf("golog: syslog: unsupported network %q", network)
+	}
+
+	w := &SyslogOutput{
+		network: network,
+		address: address,
+		opts:    opts,
+	}
+
+	if err := w.dial(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *SyslogOutput) dial() error {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	switch w.network {
+	case "":
+		for _, network := range [...]string{"unixgram", "unix"} {
+			if conn, err = net.Dial(network, w.address); err == nil {
+				break
+			}
+		}
+	case "tls":
+		conn, err = tls.Dial("tcp", w.address, w.opts.TLSConfig)
+	default:
+		conn, err = net.Dial(w.network, w.address)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.conn = conn
+	return nil
+}
+
+// Write sends "p" as one syslog message.
+func (w *SyslogOutput) Write(p []byte) (int, error) {
+	message := bytes.TrimRight(p, "\n")
+	if len(message) == 0 {
+		return len(p), nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, net.ErrClosed
+	}
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			if err = w.dial(); err != nil {
+				return 0, err
+			}
+		}
+
+		if err = w.write(message); err == nil {
+			return len(p), nil
+		}
+
+		w.conn.Close()
+		w.conn = nil
+	}
+
+	return 0, err
+}
+
+// write sends the "message", framed by octet counting over TCP and TLS
+// and by a new line over a local unix stream socket.
+func (w *SyslogOutput) write(message []byte) error {
+	var framed []byte
+	switch conn := w.conn.(type) {
+	case *net.UDPConn:
+		framed = message
+	case *net.UnixConn:
+		if conn.RemoteAddr() == nil || conn.RemoteAddr().Network() == "unixgram" {
+			framed = message
+		} else {
+			framed = append(message[:len(message):len(message)], '\n')
+		}
+	default:
+		framed = make([]byte, 0, len(message)+8)
+		framed = strconv.AppendInt(framed, int64(len(message)), 10)
+		framed = append(framed, ' ')
+		framed = append(framed, message...)
+	}
+
+	_, err := w.conn.Write(framed)
+	return err
+}
+
+// Close closes the connection.
+func (w *SyslogOutput) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
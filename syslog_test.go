@@ -0,0 +1,142 @@
+package golog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readOctetCounted reads an octet-counting framed (RFC 6587) syslog message.
+func readOctetCounted(r *bufio.Reader) (string, error) {
+	length, err := r.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+
+	n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		return "", err
+	}
+
+	message := make([]byte, n)
+	if _, err = io.ReadFull(r, message); err != nil {
+		return "", err
+	}
+
+	return string(message), nil
+}
+
+func TestSyslogOutputTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+
+		var messages []string
+		r := bufio.NewReader(conn)
+		for len(messages) < 2 {
+			message, err := readOctetCounted(r)
+			if err != nil {
+				break
+			}
+			messages = append(messages, message)
+		}
+		received <- messages
+	}()
+
+	output, err := NewSyslogOutput("tcp", ln.Addr().String(), SyslogOutputOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	logger := New().SetOutput(output)
+	logger.SetFormat("syslog", SyslogOptions{Facility: SyslogLocal0, Hostname: "host", AppName: "app"})
+	logger.Info("multi\nline")
+	logger.Errorw("second", "user_id", 42)
+
+	var messages []string
+	select {
+	case messages = <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 octet-counted messages but got %d: %q", len(messages), messages)
+	}
+
+	// local0 (16) * 8 + informational (6) and error (3).
+	if !strings.HasPrefix(messages[0], "<134>1 ") || !strings.HasSuffix(messages[0], " multi\nline") {
+		t.Fatalf("unexpected first message: %q", messages[0])
+	}
+
+	if !strings.HasPrefix(messages[1], "<131>1 ") || !strings.Contains(messages[1], ` user_id="42"]`) ||
+		!strings.HasSuffix(messages[1], " second") {
+		t.Fatalf("unexpected second message: %q", messages[1])
+	}
+}
+
+func TestSyslogOutputReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			message, err := readOctetCounted(bufio.NewReader(conn))
+			if err == nil {
+				received <- message
+			}
+
+			// reset the connection, the next write on it fails.
+			conn.(*net.TCPConn).SetLinger(0)
+			conn.Close()
+		}
+	}()
+
+	output, err := NewSyslogOutput("tcp", ln.Addr().String(), SyslogOutputOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	for _, message := range []string{"before the reset", "after the reset"} {
+		if _, err = output.Write([]byte(message + "\n")); err != nil {
+			t.Fatalf("write %q: %v", message, err)
+		}
+
+		select {
+		case got := <-received:
+			if got != message {
+				t.Fatalf("expected %q but got %q", message, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout waiting for %q", message)
+		}
+
+		time.Sleep(50 * time.Millisecond) // let the reset arrive.
+	}
+}
@@ -0,0 +1,448 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kataras/golog/printer"
+)
+
+// DefaultTextLayout is the layout of the default text output.
+const DefaultTextLayout = "{{.Level | color}} {{.Time}} {{.Caller}} {{.Prefix}}{{.Message}} {{.Fields}}{{.Errors}}"
+
+// TextFormatter is a Formatter type for text logs, configured by a layout.
+// It's the one which prints the logs when no other formatter is set.
+//
+// The layout is a text which contains {{.Element | filter arg}} tokens,
+// it's compiled once and cached. The available elements are:
+//
+//	.Time       the time formatted by the Logger's TimeFormat
+//	.Level      the level's title, e.g. [INFO]
+//	.LevelName  the level's name, e.g. info
+//	.Caller     the caller's file:line, see Logger.ReportCaller and Logger.CallerFullPath
+//	.Function   the caller's function
+//	.Prefix     the Logger's prefix
+//	.Message    the log's message
+//	.Fields     the fields as key=value pairs, nested ones with dotted keys
+//	.Errors     the indented cause list of the error fields, on new lines
+//	.Stacktrace the stacktrace, on new lines
+//
+// The available filters are:
+//
+//	pad N      pads the element with spaces on the right to N characters (left alignment)
+//	padleft N  pads the element with spaces on the left to N characters (right alignment)
+//	upper      converts the element to upper case
+//	lower      converts the element to lower case
+//	color      colors the element with the level's color
+//	color N    colors the element with the N color code, e.g. 90 for gray
+//
+// Colors are written to the outputs which support them only,
+// the color filter should be the last one.
+// Spaces between the elements are written only when both sides are not empty.
+//
+// Usage:
+//
+//	logger.SetFormat("text", "{{.Time}} {{.Level | pad 6 | color}} {{.Caller}} {{.Prefix}}{{.Message}} {{.Fields}}")
+type TextFormatter struct {
+	// Layout is the layout of the text, see `DefaultTextLayout`.
+	Layout string
+
+	layout *textLayout
+	err    error // the layout error of Options.
+}
+
+// NewTextFormatter returns a new text Formatter of the given "layout",
+// or an error if the layout is invalid.
+// If "layout" is empty then the `DefaultTextLayout` is used instead.
+func NewTextFormatter(layout string) (*TextFormatter, error) {
+	if layout == "" {
+		layout = DefaultTextLayout
+	}
+
+	compiled, err := compileTextLayout(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TextFormatter{Layout: layout, layout: compiled}, nil
+}
+
+// String returns the name of the Formatter.
+// In this case it returns "text".
+// It's used to map the formatter names with their implementations.
+func (f *TextFormatter) String() string {
+	return "text"
+}
+
+// Options sets the options for the text Formatter, the layout string,
+// and returns a new one. If the layout is invalid then the new one
+// falls back to the `DefaultTextLayout` and reports the error through its `Err` method.
+// If no layout is given then the current one is kept.
+func (f *TextFormatter) Options(opts ...any) Formatter {
+	layout := f.Layout
+	for _, opt := range opts {
+		if s, ok := opt.(string); ok {
+			layout = s
+			break
+		}
+	}
+
+	formatter, err := NewTextFormatter(layout)
+	if err != nil {
+		// fallback to the default layout, the logger reports the error.
+		return &TextFormatter{Layout: DefaultTextLayout, layout: defaultTextLayout, err: err}
+	}
+
+	return formatter
+}
+
+// Err returns the error of an invalid layout passed to `Options`,
+// the formatter uses the `DefaultTextLayout` instead.
+func (f *TextFormatter) Err() error {
+	return f.err
+}
+
+// Format prints the logs in text format.
+//
+// Usage:
+// logger.SetFormat("text", layout) or
+// logger.SetLevelFormat("info", "text", layout)
+func (f *TextFormatter) Format(dest io.Writer, log *Log) bool {
+	layout := f.layout
+	if layout == nil {
+		layout = defaultTextLayout
+	}
+
+	plainPtr := acquireBuffer()
+	defer releaseBuffer(plainPtr)
+	*plainPtr = layout.append(*plainPtr, log, false)
+
+	var err error
+	if layout.colored && printer.SupportsColor(dest) {
+		richPtr := acquireBuffer()
+		defer releaseBuffer(richPtr)
+		*richPtr = layout.append(*richPtr, log, true)
+
+		_, err = printer.WriteRichBytes(dest, *richPtr, *plainPtr)
+	} else {
+		_, err = dest.Write(*plainPtr)
+	}
+
+	return err == nil
+}
+
+type textElement int
+
+const (
+	textLiteral textElement = iota
+	textTime
+	textLevel
+	textLevelName
+	textCaller
+	textFunction
+	textPrefix
+	textMessage
+	textFields
+	textErrors
+	textStacktrace
+)
+
+var textElements = map[string]textElement{
+	"Time":       textTime,
+	"Level":      textLevel,
+	"LevelName":  textLevelName,
+	"Caller":     textCaller,
+	"Function":   textFunction,
+	"Prefix":     textPrefix,
+	"Message":    textMessage,
+	"Fields":     textFields,
+	"Errors":     textErrors,
+	"Stacktrace": textStacktrace,
+}
+
+type textFilter struct {
+	name string
+	arg  int
+}
+
+type textSegment struct {
+	element   textElement
+	literal   string
+	separator bool // a whitespace-only literal.
+	filters   []textFilter
+}
+
+type textLayout struct {
+	segments []textSegment
+	colored  bool // true if any of the segments has a color filter.
+}
+
+var (
+	textLayouts       sync.Map // compiled layouts cache.
+	defaultTextLayout = mustCompileTextLayout(DefaultTextLayout)
+)
+
+func mustCompileTextLayout(layout string) *textLayout {
+	compiled, err := compileTextLayout(layout)
+	if err != nil {
+		panic(err)
+	}
+
+	return compiled
+}
+
+// compileTextLayout parses the "layout", the result is cached.
+func compileTextLayout(layout string) (*textLayout, error) {
+	if cached, ok := textLayouts.Load(layout); ok {
+		return cached.(*textLayout), nil
+	}
+
+	compiled := new(textLayout)
+	rest := layout
+	for rest != "" {
+		start := strings.Index(rest, "{{")
+		if start == -1 {
+			compiled.addLiteral(rest)
+			break
+		}
+
+		compiled.addLiteral(rest[:start])
+		end := strings.Index(rest[start:], "}}")
+		if end == -1 {
+			return nil, fmt.Errorf("golog: text layout: unclosed token at %q", rest[start:])
+		}
+
+		segment, err := parseTextToken(rest[start+2 : start+end])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, filter := range segment.filters {
+			if filter.name == "color" {
+				compiled.colored = true
+			}
+		}
+
+		compiled.segments = append(compiled.segments, segment)
+		rest = rest[start+end+2:]
+	}
+
+	textLayouts.Store(layout, compiled)
+	return compiled, nil
+}
+
+func (t *textLayout) addLiteral(s string) {
+	if s == "" {
+		return
+	}
+
+	t.segments = append(t.segments, textSegment{
+		element:   textLiteral,
+		literal:   s,
+		separator: strings.TrimFunc(s, unicode.IsSpace) == "",
+	})
+}
+
+// parseTextToken parses a ".Element | filter arg | filter" token.
+func parseTextToken(token string) (textSegment, error) {
+	parts := strings.Split(token, "|")
+
+	name := strings.TrimSpace(parts[0])
+	element, ok := textElements[strings.TrimPrefix(name, ".")]
+	if !ok || !strings.HasPrefix(name, ".") {
+		return textSegment{}, fmt.Errorf("golog: text layout: unknown element %q", name)
+	}
+
+	segment := textSegment{element: element}
+	for _, part := range parts[1:] {
+		args := strings.Fields(part)
+		if len(args) == 0 {
+			return textSegment{}, fmt.Errorf("golog: text layout: empty filter in %q", token)
+		}
+
+		filter := textFilter{name: args[0]}
+		switch filter.name {
+		case "pad", "padleft":
+			if len(args) != 2 {
+				return textSegment{}, fmt.Errorf("golog: text layout: %s filter requires a width in %q", filter.name, token)
+			}
+		case "color":
+			if len(args) > 2 {
+				return textSegment{}, fmt.Errorf("golog: text layout: too many color filter arguments in %q", token)
+			}
+		case "upper", "lower":
+			if len(args) > 1 {
+				return textSegment{}, fmt.Errorf("golog: text layout: %s filter accepts no arguments in %q", filter.name, token)
+			}
+		default:
+			return textSegment{}, fmt.Errorf("golog: text layout: unknown filter %q", filter.name)
+		}
+
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return textSegment{}, fmt.Errorf("golog: text layout: invalid %s filter argument %q", filter.name, args[1])
+			}
+			filter.arg = n
+		}
+
+		segment.filters = append(segment.filters, filter)
+	}
+
+	return segment, nil
+}
+
+// append renders the "log" to "buf" based on the layout.
+func (t *textLayout) append(buf []byte, log *Log, colored bool) []byte {
+	var (
+		separator string
+		written   = false
+	)
+
+	for _, segment := range t.segments {
+		if segment.separator {
+			separator = segment.literal
+			continue
+		}
+
+		var value string
+		if segment.element == textLiteral {
+			value = segment.literal
+		} else {
+			value = textElementValue(segment.element, log)
+			if value == "" && !segment.hasPadding() {
+				continue
+			}
+			value = segment.filter(value, log, colored)
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if written && separator != "" && value[0] != '\n' {
+			buf = append(buf, separator...)
+		}
+		separator = ""
+		buf = append(buf, value...)
+		written = true
+	}
+
+	if log.Logger.NewLine {
+		buf = append(buf, '\n')
+	}
+
+	return buf
+}
+
+func (s textSegment) hasPadding() bool {
+	for _, filter := range s.filters {
+		if filter.name == "pad" || filter.name == "padleft" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s textSegment) filter(value string, log *Log, colored bool) string {
+	for _, filter := range s.filters {
+		switch filter.name {
+		case "pad":
+			if n := filter.arg - utf8.RuneCountInString(value); n > 0 {
+				value += strings.Repeat(" ", n)
+			}
+		case "padleft":
+			if n := filter.arg - utf8.RuneCountInString(value); n > 0 {
+				value = strings.Repeat(" ", n) + value
+			}
+		case "upper":
+			value = strings.ToUpper(value)
+		case "lower":
+			value = strings.ToLower(value)
+		case "color":
+			if !colored {
+				continue
+			}
+
+			if filter.arg > 0 {
+				value = printer.Rich(value, filter.arg)
+			} else if level, ok := Levels[log.Level]; ok && level.ColorCode > 0 {
+				value = printer.Rich(value, level.ColorCode, level.Style...)
+			}
+		}
+	}
+
+	return value
+}
+
+func textElementValue(element textElement, log *Log) string {
+	switch element {
+	case textTime:
+		return log.FormatTime()
+	case textLevel:
+		if log.Level != DisableLevel {
+			if level, ok := Levels[log.Level]; ok {
+				return level.Title
+			}
+		}
+	case textLevelName:
+		if log.Level != DisableLevel {
+			return log.Level.String()
+		}
+	case textCaller:
+		if log.Logger.CallerFullPath {
+			return log.Caller.Source
+		}
+		return log.Caller.ShortSource()
+	case textFunction:
+		return log.Caller.Function
+	case textPrefix:
+		return log.Logger.Prefix
+	case textMessage:
+		return log.Message
+	case textFields:
+		if len(log.Fields) > 0 {
+			return string(appendTextFields(nil, "", log.Fields))
+		}
+	case textErrors:
+		var b strings.Builder
+		writeErrorCauses(&b, log.Fields)
+		return b.String()
+	case textStacktrace:
+		var b strings.Builder
+		for _, frame := range log.Stacktrace {
+			fmt.Fprintf(&b, "\n    at %s (%s)", frame.Function, frame.Source)
+		}
+		return b.String()
+	}
+
+	return ""
+}
+
+// appendTextFields appends the "key=value" pairs of the "fields", in order,
+// separated by a space, nested fields are written with their keys separated by a dot.
+func appendTextFields(buf []byte, prefix string, fields FieldList) []byte {
+	for _, f := range fields {
+		if f.Value.Kind() == slog.KindGroup {
+			buf = appendTextFields(buf, prefix+f.Key+".", f.Value.Group())
+			continue
+		}
+
+		if len(buf) > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, prefix...)
+		buf = append(buf, f.Key...)
+		buf = append(buf, '=')
+		buf = append(buf, f.Value.String()...)
+	}
+
+	return buf
+}